@@ -4,15 +4,29 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 const (
-	back4appBaseUrl     = "https://parseapi.back4app.com"
-	contentTypeHeader   = "Content-type"
-	contentTypeValue    = "application/json"
-	applicationIdHeader = "X-Parse-Application-Id"
-	restApiKeyHeader    = "X-Parse-REST-API-Key"
-	sessionTokenHeader  = "X-Parse-Session-Token"
+	back4appBaseUrl      = "https://parseapi.back4app.com"
+	contentTypeHeader    = "Content-type"
+	contentTypeValue     = "application/json"
+	applicationIdHeader  = "X-Parse-Application-Id"
+	restApiKeyHeader     = "X-Parse-REST-API-Key"
+	sessionTokenHeader   = "X-Parse-Session-Token"
+	masterKeyHeader      = "X-Parse-Master-Key"
+	installationIdHeader = "X-Parse-Installation-Id"
+)
+
+const masterKeyRequiredMessage = "master key is required"
+
+// Parse Server error codes reported in Error.HostErrorCode
+const (
+	ObjectNotFound      = 101
+	OperationForbidden  = 119
+	ScriptFailed        = 141
+	ValidationError     = 142
+	InvalidSessionToken = 209
 )
 
 type Error struct {
@@ -26,22 +40,34 @@ func (r *Error) Error() string {
 }
 
 type Object struct {
-	httpClient    *http.Client
-	baseUrl       *url.URL
-	applicationId string
-	restApiKey    string
-	sessionToken  string
-}
+	httpClient     *http.Client
+	baseUrl        *url.URL
+	applicationId  string
+	restApiKey     string
+	masterKey      string
+	defaultACL     *ACL
+	installationId string
+	mu             sync.RWMutex
+	sessionToken   string
 
-type ListOptions struct {
-	Count       int
-	Limit       int
-	Skip        int
-	Order       string
-	Distinct    string
-	Constraints string
+	onInvalidSession InvalidSessionHandler
+	refresher        sessionRefresher
 }
 
+type Option func(*Object)
+
+type ListOption func(query url.Values) error
+
+type ReadPreference string
+
+const (
+	Primary            ReadPreference = "PRIMARY"
+	PrimaryPreferred   ReadPreference = "PRIMARY_PREFERRED"
+	Secondary          ReadPreference = "SECONDARY"
+	SecondaryPreferred ReadPreference = "SECONDARY_PREFERRED"
+	Nearest            ReadPreference = "NEAREST"
+)
+
 func getErrorMessage(error string, defaultError string) string {
 	if error == "" {
 		return defaultError
@@ -49,7 +75,21 @@ func getErrorMessage(error string, defaultError string) string {
 	return error
 }
 
-func NewObject(applicationId string, restApiKey string, sessionToken string, httpClient *http.Client, baseUrl *url.URL) *Object {
+func WithMasterKey(masterKey string) Option {
+	return func(c *Object) {
+		c.masterKey = masterKey
+	}
+}
+
+// WithInstallationId sends the X-Parse-Installation-Id header with every
+// request, which ties the sessions created through it to the installation.
+func WithInstallationId(installationId string) Option {
+	return func(c *Object) {
+		c.installationId = installationId
+	}
+}
+
+func NewObject(applicationId string, restApiKey string, sessionToken string, httpClient *http.Client, baseUrl *url.URL, options ...Option) *Object {
 	c := &Object{
 		httpClient:    httpClient,
 		baseUrl:       baseUrl,
@@ -63,5 +103,20 @@ func NewObject(applicationId string, restApiKey string, sessionToken string, htt
 	if c.baseUrl == nil {
 		c.baseUrl, _ = url.Parse(back4appBaseUrl)
 	}
+	for _, option := range options {
+		option(c)
+	}
 	return c
 }
+
+func (c *Object) SessionToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sessionToken
+}
+
+func (c *Object) setSessionToken(sessionToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessionToken = sessionToken
+}
@@ -0,0 +1,140 @@
+package object
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	aclField          = "ACL"
+	invalidACLMessage = "invalid ACL"
+)
+
+type Access struct {
+	Read  bool `json:"read,omitempty"`
+	Write bool `json:"write,omitempty"`
+}
+
+type ACL struct {
+	permissions map[string]Access
+}
+
+func NewACL() *ACL {
+	return &ACL{permissions: map[string]Access{}}
+}
+
+func ParseACL(object map[string]interface{}) (*ACL, error) {
+	acl := NewACL()
+	value, ok := object[aclField]
+	if !ok || value == nil {
+		return acl, nil
+	}
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(marshalled, acl); err != nil {
+		return nil, errors.New(invalidACLMessage)
+	}
+	return acl, nil
+}
+
+func (a *ACL) set(key string, read *bool, write *bool) *ACL {
+	if a.permissions == nil {
+		a.permissions = map[string]Access{}
+	}
+	access := a.permissions[key]
+	if read != nil {
+		access.Read = *read
+	}
+	if write != nil {
+		access.Write = *write
+	}
+	if !access.Read && !access.Write {
+		delete(a.permissions, key)
+		return a
+	}
+	a.permissions[key] = access
+	return a
+}
+
+func (a *ACL) SetPublicRead(allowed bool) *ACL {
+	return a.set(publicPermissionKey, &allowed, nil)
+}
+
+func (a *ACL) SetPublicWrite(allowed bool) *ACL {
+	return a.set(publicPermissionKey, nil, &allowed)
+}
+
+func (a *ACL) SetUserRead(userId string, allowed bool) *ACL {
+	return a.set(userId, &allowed, nil)
+}
+
+func (a *ACL) SetUserWrite(userId string, allowed bool) *ACL {
+	return a.set(userId, nil, &allowed)
+}
+
+func (a *ACL) SetRoleRead(roleName string, allowed bool) *ACL {
+	return a.set(rolePermissionPrefix+roleName, &allowed, nil)
+}
+
+func (a *ACL) SetRoleWrite(roleName string, allowed bool) *ACL {
+	return a.set(rolePermissionPrefix+roleName, nil, &allowed)
+}
+
+func (a *ACL) PublicRead() bool {
+	return a.permissions[publicPermissionKey].Read
+}
+
+func (a *ACL) PublicWrite() bool {
+	return a.permissions[publicPermissionKey].Write
+}
+
+func (a *ACL) UserRead(userId string) bool {
+	return a.permissions[userId].Read
+}
+
+func (a *ACL) UserWrite(userId string) bool {
+	return a.permissions[userId].Write
+}
+
+func (a *ACL) RoleRead(roleName string) bool {
+	return a.permissions[rolePermissionPrefix+roleName].Read
+}
+
+func (a *ACL) RoleWrite(roleName string) bool {
+	return a.permissions[rolePermissionPrefix+roleName].Write
+}
+
+func (a *ACL) Permissions() map[string]Access {
+	permissions := make(map[string]Access, len(a.permissions))
+	for k, v := range a.permissions {
+		permissions[k] = v
+	}
+	return permissions
+}
+
+func (a *ACL) MarshalJSON() ([]byte, error) {
+	if a.permissions == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(a.permissions)
+}
+
+func (a *ACL) UnmarshalJSON(data []byte) error {
+	var permissions map[string]Access
+	if err := json.Unmarshal(data, &permissions); err != nil {
+		return err
+	}
+	a.permissions = map[string]Access{}
+	for k, v := range permissions {
+		a.set(k, &v.Read, &v.Write)
+	}
+	return nil
+}
+
+func WithDefaultACL(acl *ACL) Option {
+	return func(c *Object) {
+		c.defaultACL = acl
+	}
+}
@@ -0,0 +1,64 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestACL(t *testing.T) {
+	acl := NewACL().
+		SetPublicRead(true).
+		SetUserRead("userId", true).
+		SetUserWrite("userId", true).
+		SetRoleWrite("admin", true)
+	assert.True(t, acl.PublicRead())
+	assert.False(t, acl.PublicWrite())
+	assert.True(t, acl.UserRead("userId"))
+	assert.True(t, acl.UserWrite("userId"))
+	assert.False(t, acl.RoleRead("admin"))
+	assert.True(t, acl.RoleWrite("admin"))
+
+	marshalled, _ := json.Marshal(acl)
+	assert.JSONEq(t, `{"*":{"read":true},"userId":{"read":true,"write":true},"role:admin":{"write":true}}`, string(marshalled))
+
+	acl.SetPublicRead(false).SetUserWrite("userId", false)
+	assert.Equal(t, map[string]Access{"userId": {Read: true}, "role:admin": {Write: true}}, acl.Permissions())
+}
+
+func TestACLRoundTrip(t *testing.T) {
+	var acl ACL
+	err := json.Unmarshal([]byte(`{"*":{"read":true},"role:admin":{"read":true,"write":true},"userId":{"read":false}}`), &acl)
+	assert.Nil(t, err)
+	assert.True(t, acl.PublicRead())
+	assert.True(t, acl.RoleRead("admin"))
+	assert.True(t, acl.RoleWrite("admin"))
+	assert.False(t, acl.UserRead("userId"))
+
+	marshalled, _ := json.Marshal(&acl)
+	assert.JSONEq(t, `{"*":{"read":true},"role:admin":{"read":true,"write":true}}`, string(marshalled))
+
+	marshalled, _ = json.Marshal(&ACL{})
+	assert.Equal(t, `{}`, string(marshalled))
+}
+
+func TestParseACL(t *testing.T) {
+	obj := map[string]interface{}{
+		"objectId": "objectId",
+		"ACL": map[string]interface{}{
+			"userId": map[string]interface{}{"read": true, "write": true},
+		},
+	}
+	acl, err := ParseACL(obj)
+	assert.Nil(t, err)
+	assert.True(t, acl.UserWrite("userId"))
+	assert.False(t, acl.PublicRead())
+
+	acl, err = ParseACL(map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.Empty(t, acl.Permissions())
+
+	acl, err = ParseACL(map[string]interface{}{"ACL": "invalid"})
+	assert.Nil(t, acl)
+	assert.EqualError(t, err, "invalid ACL")
+}
@@ -0,0 +1,145 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const unableToAggregateObjectsMessage = "unable to aggregate objects"
+
+type Pipeline struct {
+	stages []map[string]interface{}
+}
+
+type SortField struct {
+	Field     string
+	Direction int
+	// Type is set instead of Direction for the special index types, e.g. "text"
+	Type string
+}
+
+type sortStage []SortField
+
+func (s sortStage) MarshalJSON() ([]byte, error) {
+	// keep the fields in the order they were given, a map would sort them
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range s {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.Field)
+		buf.Write(key)
+		if f.Type != "" {
+			value, _ := json.Marshal(f.Type)
+			buf.WriteByte(':')
+			buf.Write(value)
+			continue
+		}
+		buf.WriteString(fmt.Sprintf(":%d", f.Direction))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func Ascending(field string) SortField {
+	return SortField{Field: field, Direction: 1}
+}
+
+func Descending(field string) SortField {
+	return SortField{Field: field, Direction: -1}
+}
+
+func NewPipeline() *Pipeline {
+	return &Pipeline{}
+}
+
+func RawPipeline(stages []map[string]interface{}) *Pipeline {
+	p := &Pipeline{}
+	p.stages = append(p.stages, stages...)
+	return p
+}
+
+func (p *Pipeline) Stage(name string, value interface{}) *Pipeline {
+	p.stages = append(p.stages, map[string]interface{}{name: value})
+	return p
+}
+
+func (p *Pipeline) Match(constraints map[string]interface{}) *Pipeline {
+	return p.Stage("$match", constraints)
+}
+
+func (p *Pipeline) Group(id interface{}, accumulators map[string]interface{}) *Pipeline {
+	group := map[string]interface{}{"_id": id}
+	for k, v := range accumulators {
+		group[k] = v
+	}
+	return p.Stage("$group", group)
+}
+
+func (p *Pipeline) Project(fields map[string]interface{}) *Pipeline {
+	return p.Stage("$project", fields)
+}
+
+func (p *Pipeline) Sort(fields ...SortField) *Pipeline {
+	return p.Stage("$sort", sortStage(fields))
+}
+
+func (p *Pipeline) Limit(i int) *Pipeline {
+	return p.Stage("$limit", i)
+}
+
+func (p *Pipeline) Skip(i int) *Pipeline {
+	return p.Stage("$skip", i)
+}
+
+func (p *Pipeline) Unwind(path string) *Pipeline {
+	return p.Stage("$unwind", path)
+}
+
+func (p *Pipeline) Count(field string) *Pipeline {
+	return p.Stage("$count", field)
+}
+
+func (p *Pipeline) Stages() []map[string]interface{} {
+	return p.stages
+}
+
+func (p *Pipeline) MarshalJSON() ([]byte, error) {
+	if p.stages == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p.stages)
+}
+
+func (c *Object) Aggregate(className string, pipeline *Pipeline) ([]map[string]interface{}, *Error) {
+	return AggregateInto[map[string]interface{}](c, className, pipeline)
+}
+
+func AggregateInto[T any](c *Object, className string, pipeline *Pipeline) ([]T, *Error) {
+	// create the query string parameters
+	marshalled, err := json.Marshal(pipeline)
+	if err != nil {
+		return nil, &Error{StatusCode: 500, Err: err}
+	}
+	q := url.Values{}
+	q.Set("pipeline", string(marshalled))
+
+	// make the request
+	var result struct {
+		Results []T `json:"results"`
+	}
+	if err := c.aggregate(className, q, &result, unableToAggregateObjectsMessage); err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
+
+func (c *Object) aggregate(className string, query url.Values, out interface{}, defaultError string) *Error {
+	// aggregate queries are only served with the master key
+	_, err := c.request("GET", fmt.Sprintf("/aggregate/%s", url.PathEscape(className)), query, nil, masterKeyAuth, out, defaultError)
+	return err
+}
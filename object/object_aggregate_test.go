@@ -0,0 +1,105 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/aggregate/className", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		assert.Equal(t, `[{"$match":{"score":{"$gt":10}}},{"$group":{"_id":"$player","total":{"$sum":"$score"}}},{"$sort":{"total":-1,"_id":1}},{"$limit":5}]`, r.URL.Query().Get("pipeline"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"objectId":"a","total":30},{"objectId":"b","total":20}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	pipeline := NewPipeline().
+		Match(map[string]interface{}{"score": map[string]interface{}{"$gt": 10}}).
+		Group("$player", map[string]interface{}{"total": map[string]interface{}{"$sum": "$score"}}).
+		Sort(Descending("total"), Ascending("_id")).
+		Limit(5)
+	results, err := c.Aggregate("className", pipeline)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "a", results[0]["objectId"])
+}
+
+func TestAggregateInto(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"objectId":"a","total":30}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	type total struct {
+		ObjectId string `json:"objectId"`
+		Total    int    `json:"total"`
+	}
+	results, err := AggregateInto[total](c, "className", NewPipeline().Count("total"))
+	assert.Nil(t, err)
+	assert.Equal(t, []total{{ObjectId: "a", Total: 30}}, results)
+}
+
+func TestAggregateRawPipeline(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `[{"$project":{"name":1}}]`, r.URL.Query().Get("pipeline"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	results, err := c.Aggregate("className", RawPipeline([]map[string]interface{}{{"$project": map[string]interface{}{"name": 1}}}))
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+}
+
+func TestAggregateMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil)
+	results, err := c.Aggregate("className", NewPipeline())
+	assert.Nil(t, results)
+	assert.Error(t, err)
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func TestAggregateError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	results, err := c.Aggregate("className", NewPipeline())
+	assert.Nil(t, results)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to aggregate objects: 400", err.Error())
+}
+
+func TestAggregateHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":1, "error":"error"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	results, err := c.Aggregate("className", NewPipeline())
+	assert.Nil(t, results)
+	assert.Error(t, err)
+	assert.Equal(t, "error: 400", err.Error())
+}
+
+func TestPipelineMarshal(t *testing.T) {
+	marshalled, _ := json.Marshal(NewPipeline())
+	assert.Equal(t, "[]", string(marshalled))
+	marshalled, _ = json.Marshal(NewPipeline().Unwind("$tags").Skip(10).Project(map[string]interface{}{"tags": 1}))
+	assert.Equal(t, `[{"$unwind":"$tags"},{"$skip":10},{"$project":{"tags":1}}]`, string(marshalled))
+}
@@ -0,0 +1,222 @@
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	publicPermissionKey           = "*"
+	requiresAuthenticationKey     = "requiresAuthentication"
+	pointerFieldsKey              = "pointerFields"
+	rolePermissionPrefix          = "role:"
+	userFieldPrefix               = "userField:"
+	unableToGetPermissionsMessage = "unable to get class level permissions of %s"
+)
+
+// Permission of an operation. An operation missing from the class level
+// permissions Parse Server answers with is open to everyone, and decodes to a
+// Permission that IsPublic.
+type Permission struct {
+	Entries                map[string]bool
+	RequiresAuthentication bool
+	PointerFields          []string
+
+	// absent marks an operation missing from the decoded permissions
+	absent bool
+}
+
+type ClassLevelPermissions struct {
+	Find            Permission          `json:"find"`
+	Get             Permission          `json:"get"`
+	Count           Permission          `json:"count"`
+	Create          Permission          `json:"create"`
+	Update          Permission          `json:"update"`
+	Delete          Permission          `json:"delete"`
+	AddField        Permission          `json:"addField"`
+	ProtectedFields map[string][]string `json:"protectedFields,omitempty"`
+	ReadUserFields  []string            `json:"readUserFields,omitempty"`
+	WriteUserFields []string            `json:"writeUserFields,omitempty"`
+}
+
+func (p *Permission) allow(key string) *Permission {
+	p.absent = false
+	if p.Entries == nil {
+		p.Entries = map[string]bool{}
+	}
+	p.Entries[key] = true
+	return p
+}
+
+func (p *Permission) AllowPublic() *Permission {
+	return p.allow(publicPermissionKey)
+}
+
+func (p *Permission) AllowUser(userId string) *Permission {
+	return p.allow(userId)
+}
+
+func (p *Permission) AllowRole(roleName string) *Permission {
+	return p.allow(rolePermissionPrefix + roleName)
+}
+
+func (p *Permission) AllowAuthenticated() *Permission {
+	p.absent = false
+	p.RequiresAuthentication = true
+	return p
+}
+
+func (p *Permission) AllowPointerFields(fields ...string) *Permission {
+	p.absent = false
+	p.PointerFields = append(p.PointerFields, fields...)
+	return p
+}
+
+func (p Permission) IsPublic() bool {
+	return p.absent || p.Entries[publicPermissionKey]
+}
+
+func (p Permission) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{}
+	for k, v := range p.Entries {
+		if v {
+			m[k] = true
+		}
+	}
+	if p.RequiresAuthentication {
+		m[requiresAuthenticationKey] = true
+	}
+	if len(p.PointerFields) > 0 {
+		m[pointerFieldsKey] = p.PointerFields
+	}
+	return json.Marshal(m)
+}
+
+func (p *Permission) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = Permission{}
+	for k, v := range m {
+		switch k {
+		case requiresAuthenticationKey:
+			if err := json.Unmarshal(v, &p.RequiresAuthentication); err != nil {
+				return err
+			}
+		case pointerFieldsKey:
+			if err := json.Unmarshal(v, &p.PointerFields); err != nil {
+				return err
+			}
+		default:
+			var allowed bool
+			if err := json.Unmarshal(v, &allowed); err != nil {
+				return err
+			}
+			if allowed {
+				p.allow(k)
+			}
+		}
+	}
+	return nil
+}
+
+// classLevelPermissions has the fields of ClassLevelPermissions without its
+// JSON methods
+type classLevelPermissions ClassLevelPermissions
+
+func (clp *ClassLevelPermissions) operations() map[string]*Permission {
+	return map[string]*Permission{
+		"find":     &clp.Find,
+		"get":      &clp.Get,
+		"count":    &clp.Count,
+		"create":   &clp.Create,
+		"update":   &clp.Update,
+		"delete":   &clp.Delete,
+		"addField": &clp.AddField,
+	}
+}
+
+func (clp ClassLevelPermissions) MarshalJSON() ([]byte, error) {
+	marshalled, err := json.Marshal(classLevelPermissions(clp))
+	if err != nil {
+		return nil, err
+	}
+	// leave the missing operations out, so they stay open as they were
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(marshalled, &m); err != nil {
+		return nil, err
+	}
+	for name, permission := range clp.operations() {
+		if permission.absent {
+			delete(m, name)
+		}
+	}
+	return json.Marshal(m)
+}
+
+func (clp *ClassLevelPermissions) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*classLevelPermissions)(clp)); err != nil {
+		return err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	for name, permission := range clp.operations() {
+		if _, ok := m[name]; !ok {
+			*permission = Permission{absent: true}
+		}
+	}
+	return nil
+}
+
+func UserFieldProtectedFieldsKey(pointerField string) string {
+	return userFieldPrefix + pointerField
+}
+
+func RoleProtectedFieldsKey(roleName string) string {
+	return rolePermissionPrefix + roleName
+}
+
+func (clp ClassLevelPermissions) PublicWriteOperations() []string {
+	var operations []string
+	for _, op := range []struct {
+		name       string
+		permission Permission
+	}{
+		{"create", clp.Create},
+		{"update", clp.Update},
+		{"delete", clp.Delete},
+		{"addField", clp.AddField},
+	} {
+		if op.permission.IsPublic() {
+			operations = append(operations, op.name)
+		}
+	}
+	return operations
+}
+
+func (clp ClassLevelPermissions) PubliclyWritable() bool {
+	return len(clp.PublicWriteOperations()) > 0
+}
+
+func (c *Object) GetClassLevelPermissions(className string) (*ClassLevelPermissions, *Error) {
+	schema, err := c.GetSchema(className)
+	if err != nil {
+		return nil, err
+	}
+	if schema.ClassLevelPermissions == nil {
+		return nil, &Error{StatusCode: 500, Err: fmt.Errorf(unableToGetPermissionsMessage, className)}
+	}
+	return schema.ClassLevelPermissions, nil
+}
+
+func (c *Object) SetClassLevelPermissions(className string, clp ClassLevelPermissions) (*ClassLevelPermissions, *Error) {
+	body := ClassSchema{ClassName: className, ClassLevelPermissions: &clp}
+	schema, err := c.updateSchema(className, body)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ClassLevelPermissions, nil
+}
@@ -0,0 +1,131 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPermissionMarshal(t *testing.T) {
+	var p Permission
+	p.AllowPublic().AllowUser("userId").AllowRole("admin").AllowAuthenticated().AllowPointerFields("owner")
+	marshalled, _ := json.Marshal(p)
+	assert.JSONEq(t, `{"*":true,"userId":true,"role:admin":true,"requiresAuthentication":true,"pointerFields":["owner"]}`, string(marshalled))
+
+	marshalled, _ = json.Marshal(Permission{})
+	assert.Equal(t, `{}`, string(marshalled))
+}
+
+func TestPermissionUnmarshal(t *testing.T) {
+	var p Permission
+	err := json.Unmarshal([]byte(`{"*":true,"role:admin":true,"userId":false,"requiresAuthentication":true,"pointerFields":["owner"]}`), &p)
+	assert.Nil(t, err)
+	assert.True(t, p.IsPublic())
+	assert.Equal(t, map[string]bool{"*": true, "role:admin": true}, p.Entries)
+	assert.True(t, p.RequiresAuthentication)
+	assert.Equal(t, []string{"owner"}, p.PointerFields)
+}
+
+func TestPubliclyWritable(t *testing.T) {
+	var clp ClassLevelPermissions
+	clp.Find.AllowPublic()
+	clp.Get.AllowPublic()
+	assert.False(t, clp.PubliclyWritable())
+
+	clp.Update.AllowPublic()
+	clp.AddField.AllowPublic()
+	clp.Delete.AllowRole("admin")
+	assert.True(t, clp.PubliclyWritable())
+	assert.Equal(t, []string{"update", "addField"}, clp.PublicWriteOperations())
+}
+
+func TestPubliclyWritableMissingOperations(t *testing.T) {
+	var clp ClassLevelPermissions
+	err := json.Unmarshal([]byte(`{"find":{"*":true},"get":{"*":true},"update":{"role:admin":true}}`), &clp)
+	assert.Nil(t, err)
+	assert.True(t, clp.PubliclyWritable())
+	assert.Equal(t, []string{"create", "delete", "addField"}, clp.PublicWriteOperations())
+	assert.True(t, clp.Count.IsPublic())
+	assert.False(t, clp.Update.IsPublic())
+
+	// the missing operations stay missing, and a permission set on one closes it
+	clp.Delete.AllowRole("admin")
+	marshalled, _ := json.Marshal(clp)
+	assert.JSONEq(t, `{"find":{"*":true},"get":{"*":true},"update":{"role:admin":true},"delete":{"role:admin":true}}`, string(marshalled))
+	assert.Equal(t, []string{"create", "addField"}, clp.PublicWriteOperations())
+}
+
+func TestGetClassLevelPermissions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/schemas/Post", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"className":"Post","classLevelPermissions":{"find":{"*":true},"get":{"*":true},"count":{},"create":{"requiresAuthentication":true},"update":{"pointerFields":["owner"]},"delete":{"role:admin":true},"addField":{},"protectedFields":{"*":["email"],"userField:owner":[]}}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	clp, err := c.GetClassLevelPermissions("Post")
+	assert.Nil(t, err)
+	assert.True(t, clp.Find.IsPublic())
+	assert.False(t, clp.Count.IsPublic())
+	assert.True(t, clp.Create.RequiresAuthentication)
+	assert.Equal(t, []string{"owner"}, clp.Update.PointerFields)
+	assert.True(t, clp.Delete.Entries["role:admin"])
+	assert.Equal(t, []string{"email"}, clp.ProtectedFields["*"])
+	assert.Equal(t, []string{}, clp.ProtectedFields[UserFieldProtectedFieldsKey("owner")])
+	assert.False(t, clp.PubliclyWritable())
+}
+
+func TestGetClassLevelPermissionsHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":103, "error":"Class Post does not exist."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	clp, err := c.GetClassLevelPermissions("Post")
+	assert.Nil(t, clp)
+	assert.Error(t, err)
+	assert.Equal(t, "Class Post does not exist.: 400", err.Error())
+}
+
+func TestSetClassLevelPermissions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"className":"Post","classLevelPermissions":{"find":{"*":true},"get":{"*":true},"count":{},"create":{"requiresAuthentication":true},"update":{"role:admin":true},"delete":{"role:admin":true},"addField":{},"protectedFields":{"*":["email"],"role:admin":[]}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	var clp ClassLevelPermissions
+	clp.Find.AllowPublic()
+	clp.Get.AllowPublic()
+	clp.Create.AllowAuthenticated()
+	clp.Update.AllowRole("admin")
+	clp.Delete.AllowRole("admin")
+	clp.ProtectedFields = map[string][]string{"*": {"email"}, RoleProtectedFieldsKey("admin"): {}}
+	updated, err := c.SetClassLevelPermissions("Post", clp)
+	assert.Nil(t, err)
+	assert.True(t, updated.Find.IsPublic())
+}
+
+func TestSetClassLevelPermissionsError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	updated, err := c.SetClassLevelPermissions("Post", ClassLevelPermissions{})
+	assert.Nil(t, updated)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to update schema: 400", err.Error())
+}
@@ -0,0 +1,251 @@
+package object
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	unableToGetConfigMessage    = "unable to get config"
+	unableToUpdateConfigMessage = "unable to update config"
+)
+
+type Config struct {
+	Params map[string]interface{} `json:"params"`
+	// MasterKeyOnly marks the params only served with the master key
+	MasterKeyOnly map[string]bool `json:"masterKeyOnly,omitempty"`
+}
+
+func (c *Config) Has(key string) bool {
+	_, ok := c.Params[key]
+	return ok
+}
+
+func (c *Config) String(key string, defaultValue string) string {
+	if s, ok := c.Params[key].(string); ok {
+		return s
+	}
+	return defaultValue
+}
+
+func (c *Config) Int(key string, defaultValue int) int {
+	if f, ok := c.Params[key].(float64); ok {
+		return int(f)
+	}
+	return defaultValue
+}
+
+func (c *Config) Float(key string, defaultValue float64) float64 {
+	if f, ok := c.Params[key].(float64); ok {
+		return f
+	}
+	return defaultValue
+}
+
+func (c *Config) Bool(key string, defaultValue bool) bool {
+	if b, ok := c.Params[key].(bool); ok {
+		return b
+	}
+	return defaultValue
+}
+
+// Date reads a Parse date, {"__type":"Date","iso":...}, or an ISO string.
+func (c *Config) Date(key string, defaultValue time.Time) time.Time {
+	v := c.Params[key]
+	if date, ok := v.(map[string]interface{}); ok && date["__type"] == "Date" {
+		v = date["iso"]
+	}
+	s, _ := v.(string)
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return defaultValue
+	}
+	return t
+}
+
+// Map returns a copy of an object param, changing it leaves the config as it is.
+func (c *Config) Map(key string, defaultValue map[string]interface{}) map[string]interface{} {
+	if m, ok := c.Params[key].(map[string]interface{}); ok {
+		return copyParam(m).(map[string]interface{})
+	}
+	return defaultValue
+}
+
+// copyParam copies the maps and slices of a decoded JSON value.
+func copyParam(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			m[k] = copyParam(value)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for i, value := range v {
+			s[i] = copyParam(value)
+		}
+		return s
+	}
+	return v
+}
+
+func (c *Config) copy() *Config {
+	config := &Config{Params: copyParam(c.Params).(map[string]interface{})}
+	if c.MasterKeyOnly != nil {
+		config.MasterKeyOnly = make(map[string]bool, len(c.MasterKeyOnly))
+		for k, v := range c.MasterKeyOnly {
+			config.MasterKeyOnly[k] = v
+		}
+	}
+	return config
+}
+
+func (c *Object) GetConfig() (*Config, *Error) {
+	var result Config
+	if _, err := c.request("GET", "/config", nil, nil, sessionAuth, &result, unableToGetConfigMessage); err != nil {
+		return nil, err
+	}
+	if result.Params == nil {
+		result.Params = map[string]interface{}{}
+	}
+	return &result, nil
+}
+
+// UpdateConfig sets the given params and leaves the others as they are. A
+// param in masterKeyOnly set to true is hidden from clients without the master
+// key.
+func (c *Object) UpdateConfig(params map[string]interface{}, masterKeyOnly map[string]bool) (bool, *Error) {
+	// the config can only be changed with the master key
+	if c.masterKey == "" {
+		return false, &Error{
+			StatusCode: http.StatusForbidden,
+			Err:        errors.New(masterKeyRequiredMessage),
+		}
+	}
+	body := Config{Params: params, MasterKeyOnly: masterKeyOnly}
+	if _, err := c.request("PUT", "/config", nil, body, sessionAuth, nil, unableToUpdateConfigMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+type CachedConfigOption func(*CachedConfig)
+
+// WithBackgroundRefresh refreshes the cached config every interval until
+// CachedConfig.Close is called, so Get rarely waits for the server.
+func WithBackgroundRefresh(interval time.Duration) CachedConfigOption {
+	return func(cc *CachedConfig) {
+		cc.refreshInterval = interval
+	}
+}
+
+type CachedConfig struct {
+	object          *Object
+	ttl             time.Duration
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu        sync.Mutex
+	config    *Config
+	fetchedAt time.Time
+	// fetches numbers each fetch, and configFetch is the one config comes from
+	fetches     uint64
+	configFetch uint64
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewCachedConfig(object *Object, ttl time.Duration, options ...CachedConfigOption) *CachedConfig {
+	cc := &CachedConfig{
+		object: object,
+		ttl:    ttl,
+		now:    time.Now,
+		stop:   make(chan struct{}),
+	}
+	for _, option := range options {
+		option(cc)
+	}
+	if cc.refreshInterval > 0 {
+		go cc.refreshLoop()
+	}
+	return cc
+}
+
+// Get returns the cached config while it is younger than the TTL, and fetches
+// it otherwise. When the fetch fails the last config is returned, so feature
+// flags keep their values while the server is unreachable. The config is a
+// copy, changing it leaves the cache as it is.
+func (cc *CachedConfig) Get() (*Config, *Error) {
+	cc.mu.Lock()
+	config, fetchedAt := cc.config, cc.fetchedAt
+	cc.mu.Unlock()
+	if config != nil && cc.now().Sub(fetchedAt) < cc.ttl {
+		return config.copy(), nil
+	}
+	fetched, err := cc.fetch()
+	if err != nil {
+		if config != nil {
+			log.Println("Error: ", err)
+			return config.copy(), nil
+		}
+		return nil, err
+	}
+	return fetched.copy(), nil
+}
+
+// Refresh fetches the config now, whatever its age.
+func (cc *CachedConfig) Refresh() *Error {
+	_, err := cc.fetch()
+	return err
+}
+
+// Close stops the background refresh.
+func (cc *CachedConfig) Close() {
+	cc.closeOnce.Do(func() {
+		close(cc.stop)
+	})
+}
+
+// fetch gets the config without holding the lock, so a slow server never
+// blocks Get on a fresh cache. A fetch that finishes after a later one keeps
+// the newer config.
+func (cc *CachedConfig) fetch() (*Config, *Error) {
+	cc.mu.Lock()
+	cc.fetches++
+	fetch := cc.fetches
+	cc.mu.Unlock()
+
+	config, err := cc.object.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if fetch < cc.configFetch {
+		return cc.config, nil
+	}
+	cc.config = config
+	cc.fetchedAt = cc.now()
+	cc.configFetch = fetch
+	return config, nil
+}
+
+func (cc *CachedConfig) refreshLoop() {
+	ticker := time.NewTicker(cc.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cc.stop:
+			return
+		case <-ticker.C:
+			if err := cc.Refresh(); err != nil {
+				log.Println("Error: ", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package object
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetConfig(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/config", r.URL.Path)
+		assert.Empty(t, r.Header.Get("X-Parse-Master-Key"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"params":{
+			"welcome":"Hello",
+			"maxItems":25,
+			"ratio":0.5,
+			"newCheckout":true,
+			"launch":{"__type":"Date","iso":"2023-01-02T03:04:05.000Z"},
+			"limits":{"daily":10}
+		}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	config, err := c.GetConfig()
+	assert.Nil(t, err)
+	assert.True(t, config.Has("welcome"))
+	assert.False(t, config.Has("missing"))
+	assert.Equal(t, "Hello", config.String("welcome", "Hi"))
+	assert.Equal(t, "Hi", config.String("missing", "Hi"))
+	assert.Equal(t, "Hi", config.String("maxItems", "Hi"))
+	assert.Equal(t, 25, config.Int("maxItems", 10))
+	assert.Equal(t, 10, config.Int("welcome", 10))
+	assert.Equal(t, 0.5, config.Float("ratio", 1))
+	assert.True(t, config.Bool("newCheckout", false))
+	assert.True(t, config.Bool("missing", true))
+	assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), config.Date("launch", time.Time{}))
+	assert.True(t, config.Date("welcome", time.Time{}).IsZero())
+	assert.Equal(t, map[string]interface{}{"daily": float64(10)}, config.Map("limits", nil))
+	assert.Nil(t, config.Map("welcome", nil))
+}
+
+func TestGetConfigMasterKeyOnly(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"params":{"secret":"s"},"masterKeyOnly":{"secret":true}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	config, err := c.GetConfig()
+	assert.Nil(t, err)
+	assert.True(t, config.MasterKeyOnly["secret"])
+}
+
+func TestGetConfigHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"code":1,"error":"Internal server error."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	config, err := c.GetConfig()
+	assert.Nil(t, config)
+	assert.Equal(t, "Internal server error.: 500", err.Error())
+}
+
+func TestUpdateConfig(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/config", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"params":{"welcome":"Hello","secret":"s"},"masterKeyOnly":{"secret":true}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":true}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	isUpdated, err := c.UpdateConfig(map[string]interface{}{"welcome": "Hello", "secret": "s"}, map[string]bool{"secret": true})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestUpdateConfigMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "", nil, nil)
+	isUpdated, err := c.UpdateConfig(map[string]interface{}{"welcome": "Hello"}, nil)
+	assert.False(t, isUpdated)
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func configServer(calls *int32, fail *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		if atomic.LoadInt32(fail) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"params":{"version":%d}}`, n)))
+	}))
+}
+
+func TestCachedConfig(t *testing.T) {
+	var calls, fail int32
+	svr := configServer(&calls, &fail)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	cc := NewCachedConfig(c, time.Minute)
+	cc.now = func() time.Time { return now }
+	defer cc.Close()
+
+	config, err := cc.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, config.Int("version", 0))
+
+	// served from the cache within the TTL
+	now = now.Add(30 * time.Second)
+	config, _ = cc.Get()
+	assert.Equal(t, 1, config.Int("version", 0))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// fetched again after the TTL
+	now = now.Add(time.Minute)
+	config, _ = cc.Get()
+	assert.Equal(t, 2, config.Int("version", 0))
+
+	// the last config is kept while the server fails
+	atomic.StoreInt32(&fail, 1)
+	now = now.Add(2 * time.Minute)
+	config, err = cc.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, config.Int("version", 0))
+	assert.Error(t, cc.Refresh())
+}
+
+func TestCachedConfigError(t *testing.T) {
+	var calls int32
+	fail := int32(1)
+	svr := configServer(&calls, &fail)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	cc := NewCachedConfig(NewObject("applicationId", "restApiKey", "", nil, b), time.Minute)
+	config, err := cc.Get()
+	assert.Nil(t, config)
+	assert.Equal(t, "unable to get config: 503", err.Error())
+}
+
+func TestCachedConfigBackgroundRefresh(t *testing.T) {
+	var calls, fail int32
+	svr := configServer(&calls, &fail)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	cc := NewCachedConfig(NewObject("applicationId", "restApiKey", "", nil, b), time.Hour, WithBackgroundRefresh(10*time.Millisecond))
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) >= 2
+	}, time.Second, 5*time.Millisecond)
+	cc.Close()
+	cc.Close()
+
+	config, err := cc.Get()
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, config.Int("version", 0), 2)
+}
+
+func TestCachedConfigSlowServer(t *testing.T) {
+	var calls int32
+	received := make(chan struct{})
+	release := make(chan struct{})
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			received <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"params":{"version":1}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	cc := NewCachedConfig(NewObject("applicationId", "restApiKey", "", nil, b), time.Hour)
+	_, err := cc.Get()
+	assert.Nil(t, err)
+
+	// a refresh waiting for the server does not block reads of the fresh cache
+	done := make(chan *Error)
+	go func() {
+		done <- cc.Refresh()
+	}()
+	<-received
+	got := make(chan *Config)
+	go func() {
+		config, _ := cc.Get()
+		got <- config
+	}()
+	select {
+	case config := <-got:
+		assert.Equal(t, 1, config.Int("version", 0))
+	case <-time.After(time.Second):
+		t.Error("Get waited for the refresh")
+	}
+	close(release)
+	assert.Nil(t, <-done)
+}
+
+func TestCachedConfigStaleFetch(t *testing.T) {
+	var calls int32
+	received := make(chan struct{})
+	release := make(chan struct{})
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := atomic.AddInt32(&calls, 1)
+		if version == 1 {
+			received <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"params":{"version":%d}}`, version)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	cc := NewCachedConfig(NewObject("applicationId", "restApiKey", "", nil, b), time.Hour)
+
+	// the first refresh answers after a later one, and keeps the newer config
+	done := make(chan *Error)
+	go func() {
+		done <- cc.Refresh()
+	}()
+	<-received
+	assert.Nil(t, cc.Refresh())
+	close(release)
+	assert.Nil(t, <-done)
+	config, err := cc.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, config.Int("version", 0))
+}
+
+func TestCachedConfigReturnsCopy(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"params":{"limits":{"items":10},"tags":["a"]}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	cc := NewCachedConfig(NewObject("applicationId", "restApiKey", "", nil, b), time.Hour)
+	config, _ := cc.Get()
+	config.Map("limits", nil)["items"] = float64(20)
+	config.Params["tags"].([]interface{})[0] = "b"
+	config.Params["added"] = true
+
+	config, _ = cc.Get()
+	assert.Equal(t, map[string]interface{}{"items": float64(10)}, config.Map("limits", nil))
+	assert.Equal(t, []interface{}{"a"}, config.Params["tags"])
+	assert.False(t, config.Has("added"))
+}
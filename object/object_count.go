@@ -0,0 +1,21 @@
+package object
+
+func (c *Object) Count(className string, option ...ListOption) (int, *Error) {
+	// create the query string parameters, a count never needs the objects themselves
+	q, err := listQuery(option)
+	if err != nil {
+		return 0, err
+	}
+	q.Set("count", "1")
+	q.Set("limit", "0")
+
+	// make the request
+	var result struct {
+		Count int `json:"count"`
+	}
+	if err := c.list(className, q, &result); err != nil {
+		return 0, err
+	}
+
+	return result.Count, nil
+}
@@ -0,0 +1,52 @@
+package object
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("count"))
+		assert.Equal(t, "0", r.URL.Query().Get("limit"))
+		assert.Equal(t, "where", r.URL.Query().Get("where"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[],"count":42}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	count, err := c.Count("className", WithConstraints("where"), WithLimit(10))
+	assert.Nil(t, err)
+	assert.Equal(t, 42, count)
+}
+
+func TestCountError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	count, err := c.Count("className")
+	assert.Equal(t, 0, count)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to list objects: 400", err.Error())
+}
+
+func TestCountHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":1, "error":"error"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	count, err := c.Count("className")
+	assert.Equal(t, 0, count)
+	assert.Error(t, err)
+	assert.Equal(t, "error: 400", err.Error())
+}
@@ -15,9 +15,19 @@ const unableToCreateObjectMessage = "unable to create object"
 
 func (c *Object) Create(className string, data map[string]interface{}) (map[string]interface{}, *Error) {
 	// create the URL
-	createUrl, _ := url.Parse(fmt.Sprintf("/classes/%s", className))
+	createUrl, _ := url.Parse(fmt.Sprintf("/classes/%s", url.PathEscape(className)))
 	createClassUrl := c.baseUrl.ResolveReference(createUrl)
 
+	// apply the default ACL without changing the caller's data
+	if _, ok := data[aclField]; !ok && c.defaultACL != nil {
+		withACL := make(map[string]interface{}, len(data)+1)
+		for k, v := range data {
+			withACL[k] = v
+		}
+		withACL[aclField] = c.defaultACL
+		data = withACL
+	}
+
 	// create the body
 	marshalled, _ := json.Marshal(data)
 
@@ -26,10 +36,10 @@ func (c *Object) Create(className string, data map[string]interface{}) (map[stri
 	req.Header.Add(contentTypeHeader, contentTypeValue)
 	req.Header.Add(applicationIdHeader, c.applicationId)
 	req.Header.Add(restApiKeyHeader, c.restApiKey)
-	req.Header.Add(sessionTokenHeader, c.sessionToken)
+	req.Header.Add(sessionTokenHeader, c.SessionToken())
 
 	// make the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return nil, &Error{StatusCode: 500, Err: err}
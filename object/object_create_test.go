@@ -1,6 +1,7 @@
 package object
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -21,6 +22,39 @@ func TestCreate(t *testing.T) {
 	assert.Equal(t, "username", obj["username"])
 }
 
+func TestCreateWithDefaultACL(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"userId": map[string]interface{}{"read": true, "write": true}}, body["ACL"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"objectId"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	acl := NewACL().SetUserRead("userId", true).SetUserWrite("userId", true)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithDefaultACL(acl))
+	data := map[string]interface{}{"name": "name"}
+	obj, _ := c.Create("className", data)
+	assert.Equal(t, "objectId", obj["objectId"])
+	assert.Equal(t, map[string]interface{}{"name": "name"}, data)
+}
+
+func TestCreateWithACLOverridesDefaultACL(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"*": map[string]interface{}{"read": true}}, body["ACL"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"objectId"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithDefaultACL(NewACL().SetUserRead("userId", true)))
+	obj, _ := c.Create("className", map[string]interface{}{"ACL": NewACL().SetPublicRead(true)})
+	assert.Equal(t, "objectId", obj["objectId"])
+}
+
 func TestCreateError(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -13,7 +13,7 @@ const unableToDeleteObjectMessage = "unable to delete object"
 
 func (c *Object) Delete(className string, id string) (bool, *Error) {
 	// create the URL
-	deleteUrl, _ := url.Parse(fmt.Sprintf("/classes/%s/%s", className, id))
+	deleteUrl, _ := url.Parse(fmt.Sprintf("/classes/%s/%s", url.PathEscape(className), url.PathEscape(id)))
 	deleteClassUrl := c.baseUrl.ResolveReference(deleteUrl)
 
 	// create the request
@@ -21,10 +21,10 @@ func (c *Object) Delete(className string, id string) (bool, *Error) {
 	req.Header.Add(contentTypeHeader, contentTypeValue)
 	req.Header.Add(applicationIdHeader, c.applicationId)
 	req.Header.Add(restApiKeyHeader, c.restApiKey)
-	req.Header.Add(sessionTokenHeader, c.sessionToken)
+	req.Header.Add(sessionTokenHeader, c.SessionToken())
 
 	// make the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return false, &Error{StatusCode: 500, Err: err}
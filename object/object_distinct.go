@@ -0,0 +1,35 @@
+package object
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+const unableToDistinctObjectsMessage = "unable to get distinct values"
+
+func (c *Object) Distinct(className string, field string, query map[string]interface{}) ([]interface{}, *Error) {
+	return DistinctInto[interface{}](c, className, field, query)
+}
+
+func DistinctInto[T any](c *Object, className string, field string, query map[string]interface{}) ([]T, *Error) {
+	// create the query string parameters
+	q := url.Values{}
+	q.Set("distinct", field)
+	if len(query) > 0 {
+		marshalled, err := json.Marshal(query)
+		if err != nil {
+			return nil, &Error{StatusCode: 500, Err: err}
+		}
+		q.Set("where", string(marshalled))
+	}
+
+	// make the request
+	var result struct {
+		Results []T `json:"results"`
+	}
+	if err := c.aggregate(className, q, &result, unableToDistinctObjectsMessage); err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
@@ -0,0 +1,76 @@
+package object
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/aggregate/className", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		assert.Equal(t, "category", r.URL.Query().Get("distinct"))
+		assert.Equal(t, `{"likes":{"$gt":100}}`, r.URL.Query().Get("where"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":["news","sport",3]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	values, err := c.Distinct("className", "category", map[string]interface{}{"likes": map[string]interface{}{"$gt": 100}})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"news", "sport", float64(3)}, values)
+}
+
+func TestDistinctInto(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := r.URL.Query()["where"]
+		assert.False(t, ok)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":["news","sport"]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	values, err := DistinctInto[string](c, "className", "category", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"news", "sport"}, values)
+}
+
+func TestDistinctMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil)
+	values, err := c.Distinct("className", "category", nil)
+	assert.Nil(t, values)
+	assert.Error(t, err)
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func TestDistinctError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	values, err := c.Distinct("className", "category", nil)
+	assert.Nil(t, values)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to get distinct values: 400", err.Error())
+}
+
+func TestDistinctHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":1, "error":"error"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	values, err := c.Distinct("className", "category", nil)
+	assert.Nil(t, values)
+	assert.Error(t, err)
+	assert.Equal(t, "error: 400", err.Error())
+}
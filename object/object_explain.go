@@ -0,0 +1,21 @@
+package object
+
+// Explain returns the query plan of the database for a list query, as the
+// document it answers with instead of the objects.
+func (c *Object) Explain(className string, option ...ListOption) (interface{}, *Error) {
+	q, err := listQuery(option)
+	if err != nil {
+		return nil, err
+	}
+	q.Set("explain", "true")
+
+	// make the request
+	var result struct {
+		Results interface{} `json:"results"`
+	}
+	if err := c.list(className, q, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
@@ -0,0 +1,37 @@
+package object
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/classes/className", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("explain"))
+		assert.Equal(t, "likes_1", r.URL.Query().Get("hint"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":{"queryPlanner":{"winningPlan":{"stage":"FETCH"}}}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	plan, err := c.Explain("className", WithHint("likes_1"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"queryPlanner": map[string]interface{}{"winningPlan": map[string]interface{}{"stage": "FETCH"}}}, plan)
+}
+
+func TestExplainError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	plan, err := c.Explain("className")
+	assert.Nil(t, plan)
+	assert.Equal(t, "unable to list objects: 400", err.Error())
+}
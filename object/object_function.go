@@ -0,0 +1,98 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+const unableToCallFunctionMessage = "unable to call function"
+
+func (c *Object) CallFunction(name string, params map[string]interface{}) (interface{}, *Error) {
+	return CallFunctionInto[interface{}](c, name, params)
+}
+
+func CallFunctionInto[T any](c *Object, name string, params map[string]interface{}) (T, *Error) {
+	var result struct {
+		Result T `json:"result"`
+	}
+
+	// create the URL
+	functionUrl, _ := url.Parse(fmt.Sprintf("/functions/%s", url.PathEscape(name)))
+	callFunctionUrl := c.baseUrl.ResolveReference(functionUrl)
+
+	// create the body
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	marshalled, _ := json.Marshal(params)
+
+	// create the request
+	req, _ := http.NewRequest("POST", callFunctionUrl.String(), bytes.NewReader(marshalled))
+	req.Header.Add(contentTypeHeader, contentTypeValue)
+	req.Header.Add(applicationIdHeader, c.applicationId)
+	req.Header.Add(restApiKeyHeader, c.restApiKey)
+	if sessionToken := c.SessionToken(); sessionToken != "" {
+		req.Header.Add(sessionTokenHeader, sessionToken)
+	}
+	if c.masterKey != "" {
+		req.Header.Add(masterKeyHeader, c.masterKey)
+	}
+
+	// make the request
+	resp, err := c.do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return result.Result, &Error{StatusCode: 500, Err: err}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code, errors thrown from cloud code are reported
+	// with their own code or ScriptFailed
+	if resp.StatusCode != http.StatusOK {
+		var errorResult map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&errorResult)
+		if errorResult == nil || (errorResult["error"] == nil && errorResult["code"] == nil) {
+			return result.Result, &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(unableToCallFunctionMessage),
+			}
+		}
+		// cloud code can throw any value, which is reported as its JSON
+		message, ok := errorResult["error"].(string)
+		if !ok && errorResult["error"] != nil {
+			marshalled, _ := json.Marshal(errorResult["error"])
+			message = string(marshalled)
+		}
+		code, ok := errorResult["code"].(float64)
+		if !ok {
+			code = ScriptFailed
+		}
+		return result.Result, &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: code,
+			Err:           errors.New(getErrorMessage(message, unableToCallFunctionMessage)),
+		}
+	}
+
+	// parse the result
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return result.Result, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+
+	return result.Result, nil
+}
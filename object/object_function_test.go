@@ -0,0 +1,104 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCallFunction(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/functions/hello", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		assert.Empty(t, r.Header.Get("X-Parse-Master-Key"))
+		var params map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		assert.Equal(t, "world", params["name"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":"hello world"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	result, err := c.CallFunction("hello", map[string]interface{}{"name": "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, "hello world", result)
+}
+
+func TestCallFunctionInto(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Parse-Session-Token"))
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":{"total":3,"names":["a","b","c"]}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	type summary struct {
+		Total int      `json:"total"`
+		Names []string `json:"names"`
+	}
+	result, err := CallFunctionInto[summary](c, "summary", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, summary{Total: 3, Names: []string{"a", "b", "c"}}, result)
+}
+
+func TestCallFunctionError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	result, err := c.CallFunction("hello", nil)
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to call function: 400", err.Error())
+}
+
+func TestCallFunctionHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":141, "error":"name is required"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	result, err := c.CallFunction("hello", nil)
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.Equal(t, "name is required: 400", err.Error())
+	assert.Equal(t, float64(ScriptFailed), err.HostErrorCode)
+}
+
+func TestCallFunctionObjectError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":141,"error":{"x":1}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	result, err := c.CallFunction("hello", nil)
+	assert.Nil(t, result)
+	assert.Equal(t, `{"x":1}: 400`, err.Error())
+	assert.Equal(t, float64(ScriptFailed), err.HostErrorCode)
+}
+
+func TestCallFunctionErrorWithoutCode(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"failed"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	_, err := c.CallFunction("hello", nil)
+	assert.Equal(t, "failed: 400", err.Error())
+	assert.Equal(t, float64(ScriptFailed), err.HostErrorCode)
+}
@@ -0,0 +1,193 @@
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+const (
+	unableToCreateInstallationMessage = "unable to create installation"
+	unableToGetInstallationMessage    = "unable to get installation"
+	unableToListInstallationsMessage  = "unable to list installations"
+	unableToUpdateInstallationMessage = "unable to update installation"
+	unableToDeleteInstallationMessage = "unable to delete installation"
+)
+
+type DeviceType string
+
+const (
+	IOSDevice     DeviceType = "ios"
+	AndroidDevice DeviceType = "android"
+	OSXDevice     DeviceType = "osx"
+	TVOSDevice    DeviceType = "tvos"
+	WebDevice     DeviceType = "web"
+)
+
+type Installation struct {
+	ObjectId         string     `json:"objectId,omitempty"`
+	InstallationId   string     `json:"installationId,omitempty"`
+	DeviceType       DeviceType `json:"deviceType,omitempty"`
+	DeviceToken      string     `json:"deviceToken,omitempty"`
+	PushType         string     `json:"pushType,omitempty"`
+	Channels         []string   `json:"channels,omitempty"`
+	Badge            *int       `json:"badge,omitempty"`
+	TimeZone         string     `json:"timeZone,omitempty"`
+	LocaleIdentifier string     `json:"localeIdentifier,omitempty"`
+	AppName          string     `json:"appName,omitempty"`
+	AppVersion       string     `json:"appVersion,omitempty"`
+	AppIdentifier    string     `json:"appIdentifier,omitempty"`
+	CreatedAt        string     `json:"createdAt,omitempty"`
+	UpdatedAt        string     `json:"updatedAt,omitempty"`
+	// Extra holds custom fields such as a user pointer, the typed fields win
+	// over the same keys here
+	Extra map[string]interface{} `json:"-"`
+}
+
+// installationFields are the JSON keys of the typed fields of Installation
+var installationFields = func() map[string]bool {
+	fields := map[string]bool{}
+	t := reflect.TypeOf(Installation{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// installation has the fields of Installation without its JSON methods
+type installation Installation
+
+func (i Installation) MarshalJSON() ([]byte, error) {
+	marshalled, err := json.Marshal(installation(i))
+	if err != nil || len(i.Extra) == 0 {
+		return marshalled, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(marshalled, &data); err != nil {
+		return nil, err
+	}
+	for k, v := range i.Extra {
+		if _, ok := data[k]; !ok {
+			data[k] = v
+		}
+	}
+	return json.Marshal(data)
+}
+
+func (i *Installation) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*installation)(i)); err != nil {
+		return err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	i.Extra = nil
+	for k, v := range raw {
+		if installationFields[k] {
+			continue
+		}
+		if i.Extra == nil {
+			i.Extra = map[string]interface{}{}
+		}
+		i.Extra[k] = v
+	}
+	return nil
+}
+
+// Badge returns a pointer for Installation.Badge, where zero clears the badge.
+func Badge(i int) *int {
+	return &i
+}
+
+// CreateInstallation creates an installation, or updates the one with the same
+// installation id or device token. The installation id defaults to the one of
+// WithInstallationId.
+func (c *Object) CreateInstallation(installation Installation) (*Installation, *Error) {
+	if installation.InstallationId == "" {
+		installation.InstallationId = c.installationId
+	}
+	var result Installation
+	if _, err := c.request("POST", "/installations", nil, installation, sessionAuth, &result, unableToCreateInstallationMessage); err != nil {
+		return nil, err
+	}
+	installation.ObjectId = result.ObjectId
+	installation.CreatedAt = result.CreatedAt
+	installation.UpdatedAt = result.UpdatedAt
+	return &installation, nil
+}
+
+func (c *Object) GetInstallation(objectId string) (*Installation, *Error) {
+	var result Installation
+	if _, err := c.request("GET", fmt.Sprintf("/installations/%s", url.PathEscape(objectId)), nil, nil, sessionAuth, &result, unableToGetInstallationMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListInstallations queries installations, which Parse Server only allows with
+// the master key.
+func (c *Object) ListInstallations(option ...ListOption) ([]Installation, *Error) {
+	q, err := listQuery(option)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Results []Installation `json:"results"`
+	}
+	if _, err := c.request("GET", "/installations", q, nil, sessionAuth, &result, unableToListInstallationsMessage); err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+// UpdateInstallation sets the fields that are not empty and leaves the others as
+// they are, the channels are cleared with SetChannels.
+func (c *Object) UpdateInstallation(objectId string, installation Installation) (bool, *Error) {
+	// the id is in the path, and the dates are set by the server
+	installation.ObjectId = ""
+	installation.CreatedAt = ""
+	installation.UpdatedAt = ""
+	return c.updateInstallation(objectId, installation)
+}
+
+// SetChannels replaces the channels of an installation, no channels clears them.
+func (c *Object) SetChannels(objectId string, channels ...string) (bool, *Error) {
+	if channels == nil {
+		channels = []string{}
+	}
+	return c.updateInstallation(objectId, map[string]interface{}{"channels": channels})
+}
+
+func (c *Object) SubscribeChannels(objectId string, channels ...string) (bool, *Error) {
+	body := map[string]interface{}{
+		"channels": map[string]interface{}{"__op": "AddUnique", "objects": channels},
+	}
+	return c.updateInstallation(objectId, body)
+}
+
+func (c *Object) UnsubscribeChannels(objectId string, channels ...string) (bool, *Error) {
+	body := map[string]interface{}{
+		"channels": map[string]interface{}{"__op": "Remove", "objects": channels},
+	}
+	return c.updateInstallation(objectId, body)
+}
+
+func (c *Object) DeleteInstallation(objectId string) (bool, *Error) {
+	if _, err := c.request("DELETE", fmt.Sprintf("/installations/%s", url.PathEscape(objectId)), nil, nil, sessionAuth, nil, unableToDeleteInstallationMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Object) updateInstallation(objectId string, body interface{}) (bool, *Error) {
+	if _, err := c.request("PUT", fmt.Sprintf("/installations/%s", url.PathEscape(objectId)), nil, body, sessionAuth, nil, unableToUpdateInstallationMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,219 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestCreateInstallation(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/installations", r.URL.Path)
+		assert.Equal(t, "installationId", r.Header.Get("X-Parse-Installation-Id"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"installationId":"installationId","deviceType":"ios","deviceToken":"deviceToken","channels":["news"],"badge":0}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"id","createdAt":"2023-01-01T00:00:00.000Z"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithInstallationId("installationId"))
+	installation, err := c.CreateInstallation(Installation{
+		DeviceType:  IOSDevice,
+		DeviceToken: "deviceToken",
+		Channels:    []string{"news"},
+		Badge:       Badge(0),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "id", installation.ObjectId)
+	assert.Equal(t, "installationId", installation.InstallationId)
+	assert.Equal(t, "2023-01-01T00:00:00.000Z", installation.CreatedAt)
+	assert.Equal(t, []string{"news"}, installation.Channels)
+}
+
+func TestCreateInstallationExisting(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"id","updatedAt":"2023-01-01T00:00:00.000Z"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	installation, err := c.CreateInstallation(Installation{InstallationId: "installationId", DeviceType: AndroidDevice})
+	assert.Nil(t, err)
+	assert.Equal(t, "id", installation.ObjectId)
+}
+
+func TestCreateInstallationHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":135,"error":"deviceType must be specified in this operation"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	installation, err := c.CreateInstallation(Installation{InstallationId: "installationId"})
+	assert.Nil(t, installation)
+	assert.Equal(t, float64(135), err.HostErrorCode)
+	assert.Equal(t, "deviceType must be specified in this operation: 400", err.Error())
+}
+
+func TestGetInstallation(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/installations/id", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"id","deviceType":"android","channels":["news","sports"],"badge":3}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	installation, err := c.GetInstallation("id")
+	assert.Nil(t, err)
+	assert.Equal(t, AndroidDevice, installation.DeviceType)
+	assert.Equal(t, []string{"news", "sports"}, installation.Channels)
+	assert.Equal(t, 3, *installation.Badge)
+}
+
+func TestGetInstallationError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	installation, err := c.GetInstallation("id")
+	assert.Nil(t, installation)
+	assert.Equal(t, "unable to get installation: 404", err.Error())
+}
+
+func TestListInstallations(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/installations", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		assert.Equal(t, `{"channels":"news","deviceType":"ios"}`, r.URL.Query().Get("where"))
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"objectId":"a"},{"objectId":"b","user":{"__type":"Pointer","className":"_User","objectId":"userId"}}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	installations, err := c.ListInstallations(WithWhere(map[string]interface{}{"channels": "news", "deviceType": IOSDevice}), WithLimit(100))
+	assert.Nil(t, err)
+	assert.Len(t, installations, 2)
+	assert.Equal(t, "b", installations[1].ObjectId)
+	assert.Nil(t, installations[0].Extra)
+	assert.Equal(t, map[string]interface{}{"__type": "Pointer", "className": "_User", "objectId": "userId"}, installations[1].Extra["user"])
+}
+
+func TestInstallationExtra(t *testing.T) {
+	installation := Installation{
+		DeviceType: AndroidDevice,
+		Extra:      map[string]interface{}{"user": map[string]interface{}{"__type": "Pointer", "className": "_User", "objectId": "userId"}, "deviceType": "ios"},
+	}
+	marshalled, err := json.Marshal(installation)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"deviceType":"android","user":{"__type":"Pointer","className":"_User","objectId":"userId"}}`, string(marshalled))
+
+	var decoded Installation
+	assert.Nil(t, json.Unmarshal(marshalled, &decoded))
+	assert.Equal(t, AndroidDevice, decoded.DeviceType)
+	assert.Equal(t, map[string]interface{}{"user": map[string]interface{}{"__type": "Pointer", "className": "_User", "objectId": "userId"}}, decoded.Extra)
+}
+
+func TestUpdateInstallation(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/installations/id", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"deviceToken":"newDeviceToken","badge":5}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"2023-01-01T00:00:00.000Z"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	isUpdated, err := c.UpdateInstallation("id", Installation{ObjectId: "id", DeviceToken: "newDeviceToken", Badge: Badge(5)})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestSetChannels(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"channels":[]}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	isUpdated, err := c.SetChannels("id")
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestSubscribeChannels(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"__op": "AddUnique", "objects": []interface{}{"news", "sports"}}, body["channels"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	isUpdated, err := c.SubscribeChannels("id", "news", "sports")
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestUnsubscribeChannels(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"__op": "Remove", "objects": []interface{}{"news"}}, body["channels"])
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b)
+	isUpdated, err := c.UnsubscribeChannels("id", "news")
+	assert.False(t, isUpdated)
+	assert.Equal(t, "unable to update installation: 400", err.Error())
+}
+
+func TestDeleteInstallation(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/installations/id", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	isDeleted, err := c.DeleteInstallation("id")
+	assert.Nil(t, err)
+	assert.True(t, isDeleted)
+}
+
+func TestInstallationIdHeader(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "installationId", r.Header.Get("X-Parse-Installation-Id"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithInstallationId("installationId"))
+	_, err := c.List("className")
+	assert.Nil(t, err)
+}
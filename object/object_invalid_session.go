@@ -0,0 +1,119 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// InvalidSessionHandler returns a new session token to retry a request
+// rejected with InvalidSessionToken, or an empty token to skip the retry.
+type InvalidSessionHandler func(sessionToken string) (string, error)
+
+func WithOnInvalidSession(handler InvalidSessionHandler) Option {
+	return func(c *Object) {
+		c.onInvalidSession = handler
+	}
+}
+
+func (c *Object) do(req *http.Request) (*http.Response, error) {
+	if c.installationId != "" && req.Header.Get(installationIdHeader) == "" {
+		req.Header.Set(installationIdHeader, c.installationId)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil || c.onInvalidSession == nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+	sessionToken := req.Header.Get(sessionTokenHeader)
+	if sessionToken == "" {
+		return resp, nil
+	}
+
+	// peek at the error code, the caller still reads the body
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var result struct {
+		Code float64 `json:"code"`
+	}
+	if json.Unmarshal(body, &result) != nil || result.Code != InvalidSessionToken {
+		return resp, nil
+	}
+
+	newSessionToken := c.refreshSession(sessionToken)
+	if newSessionToken == "" {
+		return resp, nil
+	}
+
+	// retry once with the new token
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	retry.Header.Set(sessionTokenHeader, newSessionToken)
+	return c.httpClient.Do(retry)
+}
+
+func (c *Object) refreshSession(staleToken string) string {
+	return c.refresher.refresh(staleToken, c.onInvalidSession, c.setSessionToken)
+}
+
+// sessionRefresher calls the handler once per rejected token.
+type sessionRefresher struct {
+	mu             sync.Mutex
+	inFlight       map[string]*sessionRefresh
+	staleToken     string
+	refreshedToken string
+}
+
+type sessionRefresh struct {
+	done  chan struct{}
+	token string
+}
+
+func (r *sessionRefresher) refresh(staleToken string, handler InvalidSessionHandler, refreshed func(string)) string {
+	r.mu.Lock()
+	if staleToken == r.staleToken {
+		refreshedToken := r.refreshedToken
+		r.mu.Unlock()
+		return refreshedToken
+	}
+	if call, ok := r.inFlight[staleToken]; ok {
+		r.mu.Unlock()
+		<-call.done
+		return call.token
+	}
+	if r.inFlight == nil {
+		r.inFlight = map[string]*sessionRefresh{}
+	}
+	call := &sessionRefresh{done: make(chan struct{})}
+	r.inFlight[staleToken] = call
+	r.mu.Unlock()
+
+	newSessionToken, err := handler(staleToken)
+	if err != nil {
+		log.Println("Error: ", err)
+		newSessionToken = ""
+	} else if newSessionToken != "" {
+		refreshed(newSessionToken)
+	}
+
+	r.mu.Lock()
+	delete(r.inFlight, staleToken)
+	if err == nil {
+		r.staleToken = staleToken
+		r.refreshedToken = newSessionToken
+	}
+	call.token = newSessionToken
+	r.mu.Unlock()
+	close(call.done)
+	return newSessionToken
+}
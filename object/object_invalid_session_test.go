@@ -0,0 +1,116 @@
+package object
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func invalidSessionServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Parse-Session-Token") != "newSessionToken" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":209, "error":"Invalid session token"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == "PUT" {
+			assert.JSONEq(t, `{"title":"title"}`, string(body))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"id","updatedAt":"2023-01-01T00:00:00.000Z"}`))
+	}))
+}
+
+func TestOnInvalidSessionRetries(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	var staleTokens []string
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		staleTokens = append(staleTokens, sessionToken)
+		return "newSessionToken", nil
+	}))
+	isUpdated, err := c.Update("className", "id", map[string]interface{}{"title": "title"})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+	assert.Equal(t, []string{"sessionToken"}, staleTokens)
+	assert.Equal(t, "newSessionToken", c.SessionToken())
+
+	// later requests use the new token without calling the handler
+	item, err := c.Read("className", "id")
+	assert.Nil(t, err)
+	assert.Equal(t, "id", item["objectId"])
+	assert.Len(t, staleTokens, 1)
+}
+
+func TestOnInvalidSessionNotifyOnly(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	calls := 0
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		calls++
+		return "", nil
+	}))
+	item, err := c.Read("className", "id")
+	assert.Nil(t, item)
+	assert.Equal(t, float64(InvalidSessionToken), err.HostErrorCode)
+	assert.Equal(t, "Invalid session token: 400", err.Error())
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "sessionToken", c.SessionToken())
+}
+
+func TestOnInvalidSessionHandlerError(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		return "", errors.New("unable to login")
+	}))
+	_, err := c.Read("className", "id")
+	assert.Equal(t, float64(InvalidSessionToken), err.HostErrorCode)
+}
+
+func TestOnInvalidSessionIgnoresOtherErrors(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":101, "error":"Object not found."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		t.Fail()
+		return "", nil
+	}))
+	_, err := c.Read("className", "id")
+	assert.Equal(t, "Object not found.: 404", err.Error())
+}
+
+func TestOnInvalidSessionConcurrent(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	var calls int32
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "newSessionToken", nil
+	}))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Read("className", "id")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
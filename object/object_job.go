@@ -0,0 +1,134 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	unableToStartJobMessage     = "unable to start job"
+	unableToGetJobStatusMessage = "unable to get job status"
+	jobFailedMessage            = "job failed"
+	jobTimedOutMessage          = "timed out waiting for job"
+	jobStatusIdHeader           = "X-Parse-Job-Status-Id"
+)
+
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	timeout         time.Duration
+}
+
+// WithPollInterval sets the first interval between polls, an interval that is
+// not positive keeps the default.
+func WithPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if d > 0 {
+			o.pollInterval = d
+		}
+	}
+}
+
+// WithMaxPollInterval caps the doubling interval, it is never shorter than the
+// poll interval.
+func WithMaxPollInterval(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		if d > 0 {
+			o.maxPollInterval = d
+		}
+	}
+}
+
+func WithTimeout(d time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.timeout = d
+	}
+}
+
+func (c *Object) StartJob(name string, params map[string]interface{}) (string, *Error) {
+	// jobs can only be started with the master key
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	header, err := c.request("POST", fmt.Sprintf("/jobs/%s", url.PathEscape(name)), nil, params, masterKeyAuth, nil, unableToStartJobMessage)
+	if err != nil {
+		return "", err
+	}
+
+	// the job status id is returned in a header
+	jobStatusId := header.Get(jobStatusIdHeader)
+	if jobStatusId == "" {
+		return "", &Error{
+			StatusCode: 500,
+			Err:        errors.New(unableToStartJobMessage),
+		}
+	}
+
+	return jobStatusId, nil
+}
+
+func (c *Object) JobStatus(jobStatusId string) (map[string]interface{}, *Error) {
+	// job statuses can only be read with the master key
+	var result map[string]interface{}
+	if _, err := c.request("GET", fmt.Sprintf("/classes/_JobStatus/%s", url.PathEscape(jobStatusId)), nil, nil, masterKeyAuth, &result, unableToGetJobStatusMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Object) WaitForJob(jobStatusId string, options ...WaitOption) (string, *Error) {
+	opts := &waitOptions{
+		pollInterval:    time.Second,
+		maxPollInterval: 30 * time.Second,
+		timeout:         10 * time.Minute,
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	if opts.maxPollInterval < opts.pollInterval {
+		opts.maxPollInterval = opts.pollInterval
+	}
+
+	// poll the job status, doubling the interval each time up to the maximum
+	deadline := time.Now().Add(opts.timeout)
+	interval := opts.pollInterval
+	for {
+		status, err := c.JobStatus(jobStatusId)
+		if err != nil {
+			return "", err
+		}
+		message, _ := status["message"].(string)
+		switch status["status"] {
+		case JobSucceeded:
+			return message, nil
+		case JobFailed:
+			return message, &Error{
+				StatusCode: 500,
+				Err:        errors.New(getErrorMessage(message, jobFailedMessage)),
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return message, &Error{
+				StatusCode: http.StatusRequestTimeout,
+				Err:        errors.New(jobTimedOutMessage),
+			}
+		}
+		time.Sleep(interval)
+		interval *= 2
+		if interval > opts.maxPollInterval {
+			interval = opts.maxPollInterval
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestStartJob(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/jobs/cleanup", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		var params map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+		assert.Equal(t, "all", params["scope"])
+		w.Header().Set("X-Parse-Job-Status-Id", "jobStatusId")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	jobStatusId, err := c.StartJob("cleanup", map[string]interface{}{"scope": "all"})
+	assert.Nil(t, err)
+	assert.Equal(t, "jobStatusId", jobStatusId)
+}
+
+func TestStartJobMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil)
+	jobStatusId, err := c.StartJob("cleanup", nil)
+	assert.Empty(t, jobStatusId)
+	assert.Error(t, err)
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func TestStartJobError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	jobStatusId, err := c.StartJob("cleanup", nil)
+	assert.Empty(t, jobStatusId)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to start job: 400", err.Error())
+}
+
+func TestStartJobHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":141, "error":"Invalid job."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	jobStatusId, err := c.StartJob("cleanup", nil)
+	assert.Empty(t, jobStatusId)
+	assert.Error(t, err)
+	assert.Equal(t, "Invalid job.: 400", err.Error())
+}
+
+func TestJobStatus(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/classes/_JobStatus/jobStatusId", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"jobStatusId","status":"running"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	status, err := c.JobStatus("jobStatusId")
+	assert.Nil(t, err)
+	assert.Equal(t, JobRunning, status["status"])
+}
+
+func TestJobStatusHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":101, "error":"Object not found."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	status, err := c.JobStatus("jobStatusId")
+	assert.Nil(t, status)
+	assert.Error(t, err)
+	assert.Equal(t, "Object not found.: 404", err.Error())
+}
+
+func TestWaitForJob(t *testing.T) {
+	polls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusOK)
+		if polls < 3 {
+			_, _ = w.Write([]byte(`{"status":"running","message":"working"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"succeeded","message":"cleaned 3 objects"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	message, err := c.WaitForJob("jobStatusId", WithPollInterval(time.Millisecond), WithMaxPollInterval(2*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, "cleaned 3 objects", message)
+	assert.Equal(t, 3, polls)
+}
+
+func TestWaitForJobFailed(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"failed","message":"boom"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	message, err := c.WaitForJob("jobStatusId")
+	assert.Equal(t, "boom", message)
+	assert.Error(t, err)
+	assert.Equal(t, "boom: 500", err.Error())
+}
+
+func TestWaitForJobTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"running"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	_, err := c.WaitForJob("jobStatusId", WithPollInterval(time.Millisecond), WithTimeout(5*time.Millisecond))
+	assert.Error(t, err)
+	assert.Equal(t, "timed out waiting for job: 408", err.Error())
+}
+
+func TestWaitForJobPollIntervals(t *testing.T) {
+	polls := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"running"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+
+	// a zero interval keeps the default of a second, which is past the timeout
+	_, err := c.WaitForJob("jobStatusId", WithPollInterval(0), WithMaxPollInterval(-time.Second), WithTimeout(50*time.Millisecond))
+	assert.Equal(t, "timed out waiting for job: 408", err.Error())
+	assert.Equal(t, 1, polls)
+
+	// a maximum below the interval is raised to it
+	polls = 0
+	_, err = c.WaitForJob("jobStatusId", WithPollInterval(20*time.Millisecond), WithMaxPollInterval(time.Millisecond), WithTimeout(50*time.Millisecond))
+	assert.Error(t, err)
+	assert.LessOrEqual(t, polls, 3)
+}
@@ -2,131 +2,176 @@ package object
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 const unableToListObjectsMessage = "unable to list objects"
 
-func (c *Object) List(className string, option ...ListOptions) (map[string][]map[string]interface{}, *Error) {
-	// create the URL
-	listUrl, _ := url.Parse(fmt.Sprintf("/classes/%s", className))
+type ListResult struct {
+	Results []map[string]interface{} `json:"results"`
+	// Count is only set by the server with WithCount
+	Count int `json:"count"`
+}
 
-	// create the query string parameters
-	for _, opt := range option {
-		q := listUrl.Query()
-		if opt.Count != 0 {
-			q.Set("count", fmt.Sprintf("%d", opt.Count))
-		}
-		if opt.Limit != 0 {
-			q.Set("limit", fmt.Sprintf("%d", opt.Limit))
-		}
-		if opt.Skip != 0 {
-			q.Set("skip", fmt.Sprintf("%d", opt.Skip))
-		}
-		if opt.Order != "" {
-			q.Set("order", opt.Order)
-		}
-		if opt.Distinct != "" {
-			q.Set("distinct", opt.Distinct)
-		}
-		if opt.Constraints != "" {
-			q.Set("where", opt.Constraints)
-		}
-		listUrl.RawQuery = q.Encode()
+func (c *Object) List(className string, option ...ListOption) (*ListResult, *Error) {
+	q, err := listQuery(option)
+	if err != nil {
+		return nil, err
 	}
 
-	// create the URL
-	listClassUrl := c.baseUrl.ResolveReference(listUrl)
+	// make the request
+	var result ListResult
+	if err := c.list(className, q, &result); err != nil {
+		return nil, err
+	}
 
-	// create the request
-	req, _ := http.NewRequest("GET", listClassUrl.String(), nil)
-	req.Header.Add(contentTypeHeader, contentTypeValue)
-	req.Header.Add(applicationIdHeader, c.applicationId)
-	req.Header.Add(restApiKeyHeader, c.restApiKey)
-	req.Header.Add(sessionTokenHeader, c.sessionToken)
+	return &result, nil
+}
 
-	// make the request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		log.Println("Error: ", err)
-		return nil, &Error{StatusCode: 500, Err: err}
+func listQuery(options []ListOption) (url.Values, *Error) {
+	// create the query string parameters, later options win over earlier ones
+	q := url.Values{}
+	for _, opt := range options {
+		if err := opt(q); err != nil {
+			return nil, &Error{StatusCode: 500, Err: err}
+		}
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
+	return q, nil
+}
+
+// maxPageSize is the most objects Parse Server answers with for one query
+const maxPageSize = 1000
+
+// listAllPages calls list for every page of the query, until a page is short.
+func listAllPages(options []ListOption, list func(query url.Values) ([]map[string]interface{}, *Error)) ([]map[string]interface{}, *Error) {
+	var all []map[string]interface{}
+	for skip := 0; ; skip += maxPageSize {
+		// a stable order keeps an object from showing up on two pages
+		pageOptions := append(append([]ListOption{}, options...), WithOrder("objectId"), WithSkip(skip), WithLimit(maxPageSize))
+		q, err := listQuery(pageOptions)
 		if err != nil {
-			log.Println("Error: ", err)
+			return nil, err
 		}
-	}(resp.Body)
-
-	// check the status code
-	if resp.StatusCode != http.StatusOK {
-		// parse the error result
-		var result map[string]interface{}
-		_ = json.NewDecoder(resp.Body).Decode(&result)
-		if result == nil || (result["error"] == nil && result["code"] == nil) {
-			return nil, &Error{
-				StatusCode: resp.StatusCode,
-				Err:        errors.New(unableToListObjectsMessage),
-			}
+		page, err := list(q)
+		if err != nil {
+			return nil, err
 		}
-		message := getErrorMessage(result["error"].(string), unableToListObjectsMessage)
-		return nil, &Error{
-			StatusCode:    resp.StatusCode,
-			HostErrorCode: result["code"].(float64),
-			Err:           errors.New(message),
+		all = append(all, page...)
+		if len(page) < maxPageSize {
+			return all, nil
 		}
 	}
+}
 
-	// parse the result
-	var result map[string][]map[string]interface{}
-	err = json.NewDecoder(resp.Body).Decode(&result)
-	if err != nil {
-		return nil, &Error{
-			StatusCode: 500,
-			Err:        err,
+func (c *Object) list(className string, query url.Values, out interface{}) *Error {
+	_, err := c.request("GET", fmt.Sprintf("/classes/%s", url.PathEscape(className)), query, nil, sessionAuth, out, unableToListObjectsMessage)
+	return err
+}
+
+func WithCount(i int) ListOption {
+	return func(query url.Values) error {
+		query.Set("count", strconv.Itoa(i))
+		return nil
+	}
+}
+
+func WithSkip(i int) ListOption {
+	return func(query url.Values) error {
+		query.Set("skip", strconv.Itoa(i))
+		return nil
+	}
+}
+
+func WithLimit(i int) ListOption {
+	return func(query url.Values) error {
+		query.Set("limit", strconv.Itoa(i))
+		return nil
+	}
+}
+
+func WithOrder(fields ...string) ListOption {
+	return func(query url.Values) error {
+		query.Set("order", strings.Join(fields, ","))
+		return nil
+	}
+}
+
+// Deprecated: distinct values are served from /aggregate and cannot be
+// decoded as a list of objects, use Object.Distinct instead.
+func WithDistinct(s string) ListOption {
+	return func(query url.Values) error {
+		query.Set("distinct", s)
+		return nil
+	}
+}
+
+func WithConstraints(s string) ListOption {
+	return func(query url.Values) error {
+		query.Set("where", s)
+		return nil
+	}
+}
+
+func WithWhere(constraints map[string]interface{}) ListOption {
+	return func(query url.Values) error {
+		// an empty where would match every object, so a bad constraint fails the query
+		marshalled, err := json.Marshal(constraints)
+		if err != nil {
+			return err
 		}
+		query.Set("where", string(marshalled))
+		return nil
 	}
+}
 
-	return result, nil
+func WithKeys(keys ...string) ListOption {
+	return func(query url.Values) error {
+		query.Set("keys", strings.Join(keys, ","))
+		return nil
+	}
 }
 
-func WithCount(i int) ListOptions {
-	return ListOptions{
-		Count: i,
+func WithExcludeKeys(keys ...string) ListOption {
+	return func(query url.Values) error {
+		query.Set("excludeKeys", strings.Join(keys, ","))
+		return nil
 	}
 }
 
-func WithSkip(i int) ListOptions {
-	return ListOptions{
-		Skip: i,
+func WithInclude(keys ...string) ListOption {
+	return func(query url.Values) error {
+		query.Set("include", strings.Join(keys, ","))
+		return nil
 	}
 }
 
-func WithLimit(i int) ListOptions {
-	return ListOptions{
-		Limit: i,
+func WithHint(index string) ListOption {
+	return func(query url.Values) error {
+		query.Set("hint", index)
+		return nil
 	}
 }
 
-func WithOrder(s string) ListOptions {
-	return ListOptions{
-		Order: s,
+func WithReadPreference(p ReadPreference) ListOption {
+	return func(query url.Values) error {
+		query.Set("readPreference", string(p))
+		return nil
 	}
 }
 
-func WithDistinct(s string) ListOptions {
-	return ListOptions{
-		Distinct: s,
+func WithIncludeReadPreference(p ReadPreference) ListOption {
+	return func(query url.Values) error {
+		query.Set("includeReadPreference", string(p))
+		return nil
 	}
 }
 
-func WithConstraints(s string) ListOptions {
-	return ListOptions{
-		Constraints: s,
+func WithSubqueryReadPreference(p ReadPreference) ListOption {
+	return func(query url.Values) error {
+		query.Set("subqueryReadPreference", string(p))
+		return nil
 	}
 }
@@ -18,7 +18,7 @@ func TestList(t *testing.T) {
 	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
 	list, _ := c.List("className")
 	assert.NotNil(t, list)
-	assert.Len(t, list["results"], 2)
+	assert.Len(t, list.Results, 2)
 }
 
 func TestListWithOptions(t *testing.T) {
@@ -37,7 +37,7 @@ func TestListWithOptions(t *testing.T) {
 	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
 	list, _ := c.List("className", WithCount(5), WithLimit(10), WithSkip(10), WithOrder("order"), WithDistinct("distinct"), WithConstraints("where"))
 	assert.NotNil(t, list)
-	assert.Len(t, list["results"], 2)
+	assert.Len(t, list.Results, 2)
 }
 
 func TestListError(t *testing.T) {
@@ -66,3 +66,64 @@ func TestListHostError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "error: 400", err.Error())
 }
+
+func TestListWithZeroOptions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1", r.URL.Query().Get("count"))
+		assert.Equal(t, []string{"0"}, r.URL.Query()["limit"])
+		assert.Equal(t, []string{"0"}, r.URL.Query()["skip"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[],"count":2}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	list, _ := c.List("className", WithCount(1), WithLimit(0), WithSkip(0))
+	assert.NotNil(t, list)
+	assert.Len(t, list.Results, 0)
+	assert.Equal(t, 2, list.Count)
+}
+
+func TestListWithWhereMarshalError(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil)
+	list, err := c.List("className", WithWhere(map[string]interface{}{"likes": make(chan int)}))
+	assert.Nil(t, list)
+	assert.Error(t, err)
+	assert.Equal(t, 500, err.StatusCode)
+	count, err := c.Count("className", WithWhere(map[string]interface{}{"likes": make(chan int)}))
+	assert.Equal(t, 0, count)
+	assert.Error(t, err)
+}
+
+func TestListWithQueryOptions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "-createdAt,name", r.URL.Query().Get("order"))
+		assert.Equal(t, `{"likes":{"$gt":100}}`, r.URL.Query().Get("where"))
+		assert.Equal(t, "name,likes", r.URL.Query().Get("keys"))
+		assert.Equal(t, "secret", r.URL.Query().Get("excludeKeys"))
+		assert.Equal(t, "author", r.URL.Query().Get("include"))
+		assert.Equal(t, "likes_1", r.URL.Query().Get("hint"))
+		assert.Equal(t, "SECONDARY", r.URL.Query().Get("readPreference"))
+		assert.Equal(t, "SECONDARY_PREFERRED", r.URL.Query().Get("includeReadPreference"))
+		assert.Equal(t, "NEAREST", r.URL.Query().Get("subqueryReadPreference"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"item":"item"}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	list, _ := c.List("className",
+		WithOrder("-createdAt", "name"),
+		WithConstraints("ignored"),
+		WithWhere(map[string]interface{}{"likes": map[string]interface{}{"$gt": 100}}),
+		WithKeys("name", "likes"),
+		WithExcludeKeys("secret"),
+		WithInclude("author"),
+		WithHint("likes_1"),
+		WithReadPreference(Secondary),
+		WithIncludeReadPreference(SecondaryPreferred),
+		WithSubqueryReadPreference(Nearest),
+	)
+	assert.NotNil(t, list)
+	assert.Len(t, list.Results, 1)
+}
@@ -0,0 +1,146 @@
+package object
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	unableToSendPushMessage      = "unable to send push"
+	unableToGetPushStatusMessage = "unable to get push status"
+	pushTargetRequiredMessage    = "push needs either channels or a where query"
+	pushTargetConflictMessage    = "push cannot have both channels and a where query"
+	pushStatusIdHeader           = "X-Parse-Push-Status-Id"
+	pushTimeLayout               = "2006-01-02T15:04:05.000Z"
+)
+
+const (
+	PushPending   = "pending"
+	PushScheduled = "scheduled"
+	PushRunning   = "running"
+	PushSucceeded = "succeeded"
+	PushFailed    = "failed"
+)
+
+// IncrementBadge as PushData.Badge adds one to the badge of every device.
+const IncrementBadge = "Increment"
+
+type PushData struct {
+	Alert string
+	Title string
+	// Badge is a number, or IncrementBadge
+	Badge            interface{}
+	Sound            string
+	ContentAvailable bool
+	// Custom holds app specific keys, the typed fields win over the same keys here
+	Custom map[string]interface{}
+}
+
+type Push struct {
+	// Channels and Where target installations, only one of them can be set
+	Channels []string
+	Where    map[string]interface{}
+	Data     PushData
+	// PushTime schedules the push, zero sends it now
+	PushTime           time.Time
+	ExpirationTime     time.Time
+	ExpirationInterval time.Duration
+}
+
+type PushStatus struct {
+	ObjectId      string         `json:"objectId"`
+	Status        string         `json:"status"`
+	PushTime      string         `json:"pushTime"`
+	Source        string         `json:"source"`
+	Query         string         `json:"query"`
+	Payload       string         `json:"payload"`
+	NumSent       int            `json:"numSent"`
+	NumFailed     int            `json:"numFailed"`
+	SentPerType   map[string]int `json:"sentPerType"`
+	FailedPerType map[string]int `json:"failedPerType"`
+	ErrorMessage  string         `json:"errorMessage"`
+	CreatedAt     string         `json:"createdAt"`
+	UpdatedAt     string         `json:"updatedAt"`
+}
+
+func (d PushData) MarshalJSON() ([]byte, error) {
+	data := make(map[string]interface{}, len(d.Custom)+5)
+	for k, v := range d.Custom {
+		data[k] = v
+	}
+	if d.Alert != "" {
+		data["alert"] = d.Alert
+	}
+	if d.Title != "" {
+		data["title"] = d.Title
+	}
+	if d.Badge != nil {
+		data["badge"] = d.Badge
+	}
+	if d.Sound != "" {
+		data["sound"] = d.Sound
+	}
+	if d.ContentAvailable {
+		data["content-available"] = 1
+	}
+	return json.Marshal(data)
+}
+
+func (p Push) MarshalJSON() ([]byte, error) {
+	body := map[string]interface{}{"data": p.Data}
+	if len(p.Channels) > 0 {
+		body["channels"] = p.Channels
+	}
+	if p.Where != nil {
+		body["where"] = p.Where
+	}
+	if !p.PushTime.IsZero() {
+		body["push_time"] = p.PushTime.UTC().Format(pushTimeLayout)
+	}
+	if !p.ExpirationTime.IsZero() {
+		body["expiration_time"] = p.ExpirationTime.UTC().Format(pushTimeLayout)
+	}
+	if p.ExpirationInterval > 0 {
+		body["expiration_interval"] = int(p.ExpirationInterval / time.Second)
+	}
+	return json.Marshal(body)
+}
+
+// SendPush sends or schedules a push, and returns the id of its _PushStatus.
+// The id is empty when the server accepted the push without returning one, so
+// callers must not retry then, or the devices are notified twice.
+func (c *Object) SendPush(push Push) (string, *Error) {
+	// check the target before it reaches the server
+	if len(push.Channels) == 0 && push.Where == nil {
+		return "", &Error{
+			StatusCode: http.StatusBadRequest,
+			Err:        errors.New(pushTargetRequiredMessage),
+		}
+	}
+	if len(push.Channels) > 0 && push.Where != nil {
+		return "", &Error{
+			StatusCode: http.StatusBadRequest,
+			Err:        errors.New(pushTargetConflictMessage),
+		}
+	}
+
+	header, err := c.request("POST", "/push", nil, push, masterKeyAuth, nil, unableToSendPushMessage)
+	if err != nil {
+		return "", err
+	}
+
+	// the body only holds {"result":true}, the id is in the header
+	return header.Get(pushStatusIdHeader), nil
+}
+
+func (c *Object) GetPushStatus(pushStatusId string) (*PushStatus, *Error) {
+	var result PushStatus
+	if _, err := c.request("GET", fmt.Sprintf("/classes/_PushStatus/%s", url.PathEscape(pushStatusId)), nil, nil, masterKeyAuth, &result, unableToGetPushStatusMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,156 @@
+package object
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSendPushToChannels(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/push", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"channels":["news"],
+			"data":{"alert":"Hello","title":"Title","badge":"Increment","sound":"default","content-available":1,"articleId":"a1"},
+			"push_time":"2023-01-02T03:04:05.000Z",
+			"expiration_interval":3600
+		}`, string(body))
+		w.Header().Set("X-Parse-Push-Status-Id", "pushStatusId")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":true}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	pushStatusId, err := c.SendPush(Push{
+		Channels: []string{"news"},
+		Data: PushData{
+			Alert:            "Hello",
+			Title:            "Title",
+			Badge:            IncrementBadge,
+			Sound:            "default",
+			ContentAvailable: true,
+			Custom:           map[string]interface{}{"articleId": "a1", "alert": "ignored"},
+		},
+		PushTime:           time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		ExpirationInterval: time.Hour,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "pushStatusId", pushStatusId)
+}
+
+func TestSendPushToQuery(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"where":{"deviceType":"ios","channels":{"$in":["news","sports"]}},
+			"data":{"alert":"Hello","badge":0},
+			"expiration_time":"2023-01-02T03:04:05.000Z"
+		}`, string(body))
+		w.Header().Set("X-Parse-Push-Status-Id", "pushStatusId")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":true}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	_, err := c.SendPush(Push{
+		Where:          map[string]interface{}{"deviceType": IOSDevice, "channels": map[string]interface{}{"$in": []string{"news", "sports"}}},
+		Data:           PushData{Alert: "Hello", Badge: 0},
+		ExpirationTime: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	assert.Nil(t, err)
+}
+
+func TestSendPushStatusIdMissing(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"result":true}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	pushStatusId, err := c.SendPush(Push{Channels: []string{"news"}, Data: PushData{Alert: "Hello"}})
+	// the push was sent, so there is no error a caller could retry on
+	assert.Nil(t, err)
+	assert.Empty(t, pushStatusId)
+}
+
+func TestSendPushTarget(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "", nil, nil, WithMasterKey("masterKey"))
+	_, err := c.SendPush(Push{Data: PushData{Alert: "Hello"}})
+	assert.Equal(t, "push needs either channels or a where query: 400", err.Error())
+	_, err = c.SendPush(Push{Channels: []string{"news"}, Where: map[string]interface{}{}, Data: PushData{Alert: "Hello"}})
+	assert.Equal(t, "push cannot have both channels and a where query: 400", err.Error())
+}
+
+func TestSendPushMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "", nil, nil)
+	_, err := c.SendPush(Push{Channels: []string{"news"}})
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func TestSendPushHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":115,"error":"Missing push configuration"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	pushStatusId, err := c.SendPush(Push{Channels: []string{"news"}})
+	assert.Empty(t, pushStatusId)
+	assert.Equal(t, float64(115), err.HostErrorCode)
+	assert.Equal(t, "Missing push configuration: 400", err.Error())
+}
+
+func TestGetPushStatus(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/classes/_PushStatus/pushStatusId", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"pushStatusId","status":"succeeded","numSent":3,"numFailed":1,"sentPerType":{"ios":3},"failedPerType":{"android":1}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	status, err := c.GetPushStatus("pushStatusId")
+	assert.Nil(t, err)
+	assert.Equal(t, PushSucceeded, status.Status)
+	assert.Equal(t, 3, status.NumSent)
+	assert.Equal(t, map[string]int{"android": 1}, status.FailedPerType)
+}
+
+func TestGetPushStatusEscapesId(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/classes/_PushStatus/a%2Fb%3Fc", r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"a/b?c"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	status, err := c.GetPushStatus("a/b?c")
+	assert.Nil(t, err)
+	assert.Equal(t, "a/b?c", status.ObjectId)
+}
+
+func TestGetPushStatusError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	status, err := c.GetPushStatus("pushStatusId")
+	assert.Nil(t, status)
+	assert.Equal(t, "unable to get push status: 404", err.Error())
+}
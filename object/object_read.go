@@ -14,7 +14,7 @@ const unableToReadObjectMessage = "unable to read object"
 
 func (c *Object) Read(className string, id string) (map[string]interface{}, *Error) {
 	// create the URL
-	readUrl, _ := url.Parse(fmt.Sprintf("/classes/%s/%s", className, id))
+	readUrl, _ := url.Parse(fmt.Sprintf("/classes/%s/%s", url.PathEscape(className), url.PathEscape(id)))
 	readClassUrl := c.baseUrl.ResolveReference(readUrl)
 
 	// create the request
@@ -22,10 +22,10 @@ func (c *Object) Read(className string, id string) (map[string]interface{}, *Err
 	req.Header.Add(contentTypeHeader, contentTypeValue)
 	req.Header.Add(applicationIdHeader, c.applicationId)
 	req.Header.Add(restApiKeyHeader, c.restApiKey)
-	req.Header.Add(sessionTokenHeader, c.sessionToken)
+	req.Header.Add(sessionTokenHeader, c.SessionToken())
 
 	// make the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return nil, &Error{StatusCode: 500, Err: err}
@@ -0,0 +1,106 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+type requestAuth int
+
+const (
+	// sessionAuth sends the session token and the master key when they are set
+	sessionAuth requestAuth = iota
+	// masterKeyAuth fails without the master key, and never sends the session token
+	masterKeyAuth
+)
+
+// request sends a request to the REST API and decodes the result into out,
+// unless out is nil. The headers of the response are returned for the
+// endpoints that answer with an id in a header.
+func (c *Object) request(method string, path string, query url.Values, body interface{}, auth requestAuth, out interface{}, defaultError string) (http.Header, *Error) {
+	if auth == masterKeyAuth && c.masterKey == "" {
+		return nil, &Error{
+			StatusCode: http.StatusForbidden,
+			Err:        errors.New(masterKeyRequiredMessage),
+		}
+	}
+
+	// create the URL
+	pathUrl, _ := url.Parse(path)
+	pathUrl.RawQuery = query.Encode()
+	requestUrl := c.baseUrl.ResolveReference(pathUrl)
+
+	// create the body
+	var reader io.Reader
+	if body != nil {
+		marshalled, err := json.Marshal(body)
+		if err != nil {
+			return nil, &Error{StatusCode: 500, Err: err}
+		}
+		reader = bytes.NewReader(marshalled)
+	}
+
+	// create the request
+	req, _ := http.NewRequest(method, requestUrl.String(), reader)
+	req.Header.Add(contentTypeHeader, contentTypeValue)
+	req.Header.Add(applicationIdHeader, c.applicationId)
+	req.Header.Add(restApiKeyHeader, c.restApiKey)
+	if sessionToken := c.SessionToken(); sessionToken != "" && auth == sessionAuth {
+		req.Header.Add(sessionTokenHeader, sessionToken)
+	}
+	if c.masterKey != "" {
+		req.Header.Add(masterKeyHeader, c.masterKey)
+	}
+
+	// make the request
+	resp, err := c.do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return nil, &Error{StatusCode: 500, Err: err}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code, a create answers 201
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		// parse the error result
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result == nil || (result["error"] == nil && result["code"] == nil) {
+			return nil, &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(defaultError),
+			}
+		}
+		message, _ := result["error"].(string)
+		code, _ := result["code"].(float64)
+		return nil, &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: code,
+			Err:           errors.New(getErrorMessage(message, defaultError)),
+		}
+	}
+
+	// parse the result
+	if out == nil {
+		return resp.Header, nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return nil, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+
+	return resp.Header, nil
+}
@@ -0,0 +1,109 @@
+package object
+
+import "net/url"
+
+const (
+	roleClassName = "_Role"
+	userClassName = "_User"
+)
+
+func pointer(className string, objectId string) map[string]interface{} {
+	return map[string]interface{}{
+		"__type":    "Pointer",
+		"className": className,
+		"objectId":  objectId,
+	}
+}
+
+func relationOp(op string, className string, objectIds []string) map[string]interface{} {
+	objects := make([]map[string]interface{}, 0, len(objectIds))
+	for _, id := range objectIds {
+		objects = append(objects, pointer(className, id))
+	}
+	return map[string]interface{}{
+		"__op":    op,
+		"objects": objects,
+	}
+}
+
+func (c *Object) CreateRole(name string, acl *ACL) (map[string]interface{}, *Error) {
+	data := map[string]interface{}{"name": name}
+	if acl != nil {
+		data[aclField] = acl
+	}
+	return c.Create(roleClassName, data)
+}
+
+func (c *Object) DeleteRole(roleId string) (bool, *Error) {
+	return c.Delete(roleClassName, roleId)
+}
+
+func (c *Object) AddRoleUsers(roleId string, userIds ...string) (bool, *Error) {
+	return c.Update(roleClassName, roleId, map[string]interface{}{
+		"users": relationOp("AddRelation", userClassName, userIds),
+	})
+}
+
+func (c *Object) RemoveRoleUsers(roleId string, userIds ...string) (bool, *Error) {
+	return c.Update(roleClassName, roleId, map[string]interface{}{
+		"users": relationOp("RemoveRelation", userClassName, userIds),
+	})
+}
+
+func (c *Object) AddChildRoles(roleId string, childRoleIds ...string) (bool, *Error) {
+	return c.Update(roleClassName, roleId, map[string]interface{}{
+		"roles": relationOp("AddRelation", roleClassName, childRoleIds),
+	})
+}
+
+func (c *Object) RemoveChildRoles(roleId string, childRoleIds ...string) (bool, *Error) {
+	return c.Update(roleClassName, roleId, map[string]interface{}{
+		"roles": relationOp("RemoveRelation", roleClassName, childRoleIds),
+	})
+}
+
+func (c *Object) UserRoles(userId string) ([]map[string]interface{}, *Error) {
+	// the roles the user is a direct member of
+	roles, err := c.listRoles(map[string]interface{}{"users": pointer(userClassName, userId)})
+	if err != nil {
+		return nil, err
+	}
+
+	// walk up the hierarchy, a role inherits from the roles that list it in their roles relation
+	seen := map[string]bool{}
+	var frontier []interface{}
+	for _, role := range roles {
+		id, _ := role["objectId"].(string)
+		seen[id] = true
+		frontier = append(frontier, pointer(roleClassName, id))
+	}
+	for len(frontier) > 0 {
+		parents, err := c.listRoles(map[string]interface{}{"roles": map[string]interface{}{"$in": frontier}})
+		if err != nil {
+			return nil, err
+		}
+		frontier = nil
+		for _, role := range parents {
+			id, _ := role["objectId"].(string)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			roles = append(roles, role)
+			frontier = append(frontier, pointer(roleClassName, id))
+		}
+	}
+	return roles, nil
+}
+
+func (c *Object) listRoles(where map[string]interface{}) ([]map[string]interface{}, *Error) {
+	return listAllPages([]ListOption{WithWhere(where)}, func(query url.Values) ([]map[string]interface{}, *Error) {
+		var result struct {
+			Results []map[string]interface{} `json:"results"`
+		}
+		if err := c.list(roleClassName, query, &result); err != nil {
+			return nil, err
+		}
+		return result.Results, nil
+	})
+}
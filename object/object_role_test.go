@@ -0,0 +1,172 @@
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestCreateRole(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/classes/_Role", r.URL.Path)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "admin", body["name"])
+		assert.Equal(t, map[string]interface{}{"*": map[string]interface{}{"read": true}}, body["ACL"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"roleId"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	role, err := c.CreateRole("admin", NewACL().SetPublicRead(true))
+	assert.Nil(t, err)
+	assert.Equal(t, "roleId", role["objectId"])
+}
+
+func TestCreateRoleHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":137, "error":"A duplicate value for a field with unique values was provided"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	role, err := c.CreateRole("admin", nil)
+	assert.Nil(t, role)
+	assert.Error(t, err)
+	assert.Equal(t, "A duplicate value for a field with unique values was provided: 400", err.Error())
+}
+
+func TestAddRoleUsers(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/classes/_Role/roleId", r.URL.Path)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{
+			"__op": "AddRelation",
+			"objects": []interface{}{
+				map[string]interface{}{"__type": "Pointer", "className": "_User", "objectId": "a"},
+				map[string]interface{}{"__type": "Pointer", "className": "_User", "objectId": "b"},
+			},
+		}, body["users"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	isUpdated, err := c.AddRoleUsers("roleId", "a", "b")
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestRemoveChildRoles(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		roles := body["roles"].(map[string]interface{})
+		assert.Equal(t, "RemoveRelation", roles["__op"])
+		assert.Equal(t, "_Role", roles["objects"].([]interface{})[0].(map[string]interface{})["className"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	isUpdated, err := c.RemoveChildRoles("roleId", "childId")
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestDeleteRole(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/classes/_Role/roleId", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	isDeleted, err := c.DeleteRole("roleId")
+	assert.Nil(t, err)
+	assert.True(t, isDeleted)
+}
+
+func TestUserRoles(t *testing.T) {
+	// moderator is a child of admin, admin is a child of owner, and owner is a child of moderator
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var where map[string]interface{}
+		_ = json.Unmarshal([]byte(r.URL.Query().Get("where")), &where)
+		w.WriteHeader(http.StatusOK)
+		if users, ok := where["users"]; ok {
+			assert.Equal(t, "userId", users.(map[string]interface{})["objectId"])
+			_, _ = w.Write([]byte(`{"results":[{"objectId":"moderator","name":"moderator"}]}`))
+			return
+		}
+		in := where["roles"].(map[string]interface{})["$in"].([]interface{})
+		switch in[0].(map[string]interface{})["objectId"] {
+		case "moderator":
+			_, _ = w.Write([]byte(`{"results":[{"objectId":"admin","name":"admin"}]}`))
+		case "admin":
+			_, _ = w.Write([]byte(`{"results":[{"objectId":"owner","name":"owner"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"results":[{"objectId":"moderator","name":"moderator"}]}`))
+		}
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	roles, err := c.UserRoles("userId")
+	assert.Nil(t, err)
+	var names []string
+	for _, role := range roles {
+		names = append(names, role["name"].(string))
+	}
+	assert.Equal(t, []string{"moderator", "admin", "owner"}, names)
+}
+
+func TestUserRolesPages(t *testing.T) {
+	// the user is in 1001 roles, none of which has a parent
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var where map[string]interface{}
+		_ = json.Unmarshal([]byte(r.URL.Query().Get("where")), &where)
+		w.WriteHeader(http.StatusOK)
+		if _, ok := where["users"]; !ok {
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		assert.Equal(t, "objectId", r.URL.Query().Get("order"))
+		assert.Equal(t, "1000", r.URL.Query().Get("limit"))
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		results := make([]map[string]interface{}, 0, 1000)
+		for i := skip; i < 1001 && i < skip+1000; i++ {
+			results = append(results, map[string]interface{}{"objectId": fmt.Sprintf("role%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	roles, err := c.UserRoles("userId")
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1001)
+	assert.Equal(t, "role1000", roles[1000]["objectId"])
+}
+
+func TestUserRolesError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	roles, err := c.UserRoles("userId")
+	assert.Nil(t, roles)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to list objects: 400", err.Error())
+}
@@ -0,0 +1,173 @@
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	unableToListSchemasMessage  = "unable to list schemas"
+	unableToGetSchemaMessage    = "unable to get schema"
+	unableToCreateClassMessage  = "unable to create class"
+	unableToUpdateSchemaMessage = "unable to update schema"
+	unableToDropClassMessage    = "unable to drop class"
+)
+
+type FieldType string
+
+const (
+	StringField   FieldType = "String"
+	NumberField   FieldType = "Number"
+	BooleanField  FieldType = "Boolean"
+	DateField     FieldType = "Date"
+	PointerField  FieldType = "Pointer"
+	RelationField FieldType = "Relation"
+	ArrayField    FieldType = "Array"
+	ObjectField   FieldType = "Object"
+	FileField     FieldType = "File"
+	GeoPointField FieldType = "GeoPoint"
+	PolygonField  FieldType = "Polygon"
+	BytesField    FieldType = "Bytes"
+	ACLField      FieldType = "ACL"
+)
+
+type Field struct {
+	Type         FieldType   `json:"type"`
+	TargetClass  string      `json:"targetClass,omitempty"`
+	Required     bool        `json:"required,omitempty"`
+	DefaultValue interface{} `json:"defaultValue,omitempty"`
+}
+
+func PointerTo(className string) Field {
+	return Field{Type: PointerField, TargetClass: className}
+}
+
+func RelationTo(className string) Field {
+	return Field{Type: RelationField, TargetClass: className}
+}
+
+type Index []SortField
+
+func TextIndex(field string) SortField {
+	return SortField{Field: field, Type: "text"}
+}
+
+func GeoIndex(field string) SortField {
+	return SortField{Field: field, Type: "2dsphere"}
+}
+
+func HashedIndex(field string) SortField {
+	return SortField{Field: field, Type: "hashed"}
+}
+
+func (i Index) MarshalJSON() ([]byte, error) {
+	return sortStage(i).MarshalJSON()
+}
+
+func (i *Index) UnmarshalJSON(data []byte) error {
+	// decode token by token, the order of the keys is the order of the index
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	*i = Index{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		// a number is a direction, a string one of the special index types
+		field := SortField{Field: key.(string)}
+		switch v := value.(type) {
+		case float64:
+			field.Direction = int(v)
+		case string:
+			field.Type = v
+		}
+		*i = append(*i, field)
+	}
+	return nil
+}
+
+type ClassSchema struct {
+	ClassName             string                 `json:"className"`
+	Fields                map[string]Field       `json:"fields,omitempty"`
+	ClassLevelPermissions *ClassLevelPermissions `json:"classLevelPermissions,omitempty"`
+	Indexes               map[string]Index       `json:"indexes,omitempty"`
+}
+
+func (c *Object) ListSchemas() ([]ClassSchema, *Error) {
+	var result struct {
+		Results []ClassSchema `json:"results"`
+	}
+	if _, err := c.request("GET", "/schemas", nil, nil, masterKeyAuth, &result, unableToListSchemasMessage); err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+func (c *Object) GetSchema(className string) (*ClassSchema, *Error) {
+	var result ClassSchema
+	if _, err := c.request("GET", fmt.Sprintf("/schemas/%s", url.PathEscape(className)), nil, nil, masterKeyAuth, &result, unableToGetSchemaMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Object) CreateClass(className string, fields map[string]Field) (*ClassSchema, *Error) {
+	body := ClassSchema{ClassName: className, Fields: fields}
+	var result ClassSchema
+	if _, err := c.request("POST", fmt.Sprintf("/schemas/%s", url.PathEscape(className)), nil, body, masterKeyAuth, &result, unableToCreateClassMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Object) AddFields(className string, fields map[string]Field) (*ClassSchema, *Error) {
+	body := ClassSchema{ClassName: className, Fields: fields}
+	return c.updateSchema(className, body)
+}
+
+func (c *Object) DeleteFields(className string, names ...string) (*ClassSchema, *Error) {
+	fields := map[string]interface{}{}
+	for _, name := range names {
+		fields[name] = map[string]string{"__op": "Delete"}
+	}
+	body := map[string]interface{}{"className": className, "fields": fields}
+	return c.updateSchema(className, body)
+}
+
+func (c *Object) SetIndexes(className string, indexes map[string]Index) (*ClassSchema, *Error) {
+	body := ClassSchema{ClassName: className, Indexes: indexes}
+	return c.updateSchema(className, body)
+}
+
+func (c *Object) DeleteIndexes(className string, names ...string) (*ClassSchema, *Error) {
+	indexes := map[string]interface{}{}
+	for _, name := range names {
+		indexes[name] = map[string]string{"__op": "Delete"}
+	}
+	body := map[string]interface{}{"className": className, "indexes": indexes}
+	return c.updateSchema(className, body)
+}
+
+func (c *Object) DropClass(className string) (bool, *Error) {
+	if _, err := c.request("DELETE", fmt.Sprintf("/schemas/%s", url.PathEscape(className)), nil, nil, masterKeyAuth, nil, unableToDropClassMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Object) updateSchema(className string, body interface{}) (*ClassSchema, *Error) {
+	var result ClassSchema
+	if _, err := c.request("PUT", fmt.Sprintf("/schemas/%s", url.PathEscape(className)), nil, body, masterKeyAuth, &result, unableToUpdateSchemaMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,379 @@
+package object
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	parseTag                      = "parse"
+	destructiveChangesMessage     = "migration plan contains destructive changes"
+	unableToInferFieldTypeMessage = "unable to infer parse type of field %s.%s, set it with type="
+)
+
+type ChangeKind string
+
+const (
+	CreateClassChange ChangeKind = "create class"
+	AddFieldChange    ChangeKind = "add field"
+	DeleteFieldChange ChangeKind = "delete field"
+	ChangeFieldChange ChangeKind = "change field"
+)
+
+type Change struct {
+	Kind      ChangeKind
+	ClassName string
+	FieldName string
+	Field     Field
+	Current   Field
+}
+
+type Plan struct {
+	Changes []Change
+}
+
+type classNamer interface {
+	ClassName() string
+}
+
+// fields every class has, which are never created or deleted by a migration
+var defaultFields = map[string]bool{
+	"objectId":  true,
+	"createdAt": true,
+	"updatedAt": true,
+	"ACL":       true,
+}
+
+// fields the system classes have on top of the default fields
+var systemFields = map[string]map[string]bool{
+	"_User": {
+		"username":      true,
+		"password":      true,
+		"email":         true,
+		"emailVerified": true,
+		"authData":      true,
+	},
+	"_Installation": {
+		"installationId":   true,
+		"deviceToken":      true,
+		"channels":         true,
+		"deviceType":       true,
+		"pushType":         true,
+		"GCMSenderId":      true,
+		"timeZone":         true,
+		"localeIdentifier": true,
+		"badge":            true,
+		"appVersion":       true,
+		"appName":          true,
+		"appIdentifier":    true,
+		"parseVersion":     true,
+	},
+	"_Role": {
+		"name":  true,
+		"users": true,
+		"roles": true,
+	},
+	"_Session": {
+		"user":           true,
+		"installationId": true,
+		"sessionToken":   true,
+		"expiresAt":      true,
+		"createdWith":    true,
+	},
+	"_PushStatus": {
+		"pushTime":            true,
+		"source":              true,
+		"query":               true,
+		"payload":             true,
+		"title":               true,
+		"expiry":              true,
+		"expiration_interval": true,
+		"status":              true,
+		"numSent":             true,
+		"numFailed":           true,
+		"pushHash":            true,
+		"errorMessage":        true,
+		"sentPerType":         true,
+		"failedPerType":       true,
+		"sentPerUTCOffset":    true,
+		"failedPerUTCOffset":  true,
+		"count":               true,
+	},
+}
+
+func (c Change) Destructive() bool {
+	return c.Kind == DeleteFieldChange || c.Kind == ChangeFieldChange
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case CreateClassChange:
+		return fmt.Sprintf("+ create class %s", c.ClassName)
+	case AddFieldChange:
+		return fmt.Sprintf("+ add field %s.%s %s", c.ClassName, c.FieldName, describeField(c.Field))
+	case DeleteFieldChange:
+		return fmt.Sprintf("- delete field %s.%s %s (destructive)", c.ClassName, c.FieldName, describeField(c.Current))
+	default:
+		return fmt.Sprintf("~ change field %s.%s %s -> %s (destructive)", c.ClassName, c.FieldName, describeField(c.Current), describeField(c.Field))
+	}
+}
+
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (p Plan) Destructive() bool {
+	for _, change := range p.Changes {
+		if change.Destructive() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Plan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+	lines := make([]string, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func describeField(f Field) string {
+	s := string(f.Type)
+	if f.TargetClass != "" {
+		s = fmt.Sprintf("%s<%s>", s, f.TargetClass)
+	}
+	if f.Required {
+		s += " required"
+	}
+	if f.DefaultValue != nil {
+		value, _ := json.Marshal(f.DefaultValue)
+		s += fmt.Sprintf(" default=%s", value)
+	}
+	return s
+}
+
+func SchemaFromStruct(model interface{}) (ClassSchema, error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return ClassSchema{}, fmt.Errorf("model must be a struct, got %T", model)
+	}
+
+	// the class name defaults to the struct name
+	className := t.Name()
+	if namer, ok := model.(classNamer); ok {
+		className = namer.ClassName()
+	}
+
+	schema := ClassSchema{ClassName: className, Fields: map[string]Field{}}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup(parseTag)
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		name, field, err := fieldFromTag(sf, tag)
+		if err != nil {
+			return ClassSchema{}, fmt.Errorf("%s: %w", className, err)
+		}
+		if field.Type == "" {
+			return ClassSchema{}, fmt.Errorf(unableToInferFieldTypeMessage, className, name)
+		}
+		schema.Fields[name] = field
+	}
+	return schema, nil
+}
+
+func SchemasFromStructs(models ...interface{}) ([]ClassSchema, error) {
+	schemas := make([]ClassSchema, 0, len(models))
+	for _, model := range models {
+		schema, err := SchemaFromStruct(model)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+func fieldFromTag(sf reflect.StructField, tag string) (string, Field, error) {
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = sf.Name
+	}
+	field := Field{Type: inferFieldType(sf.Type)}
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "required":
+			field.Required = true
+		case "type":
+			field.Type = FieldType(value)
+		case "pointer":
+			field.Type = PointerField
+			field.TargetClass = value
+		case "relation":
+			field.Type = RelationField
+			field.TargetClass = value
+		case "default":
+			// a JSON value, or else a string
+			if json.Unmarshal([]byte(value), &field.DefaultValue) != nil {
+				field.DefaultValue = value
+			}
+		default:
+			return "", Field{}, fmt.Errorf("unknown option %q on field %s", key, name)
+		}
+	}
+	return name, field, nil
+}
+
+func inferFieldType(t reflect.Type) FieldType {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return DateField
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return StringField
+	case reflect.Bool:
+		return BooleanField
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return NumberField
+	case reflect.Slice, reflect.Array:
+		return ArrayField
+	case reflect.Map, reflect.Struct:
+		return ObjectField
+	}
+	return ""
+}
+
+func Diff(desired []ClassSchema, live []ClassSchema) Plan {
+	liveByName := map[string]ClassSchema{}
+	for _, schema := range live {
+		liveByName[schema.ClassName] = schema
+	}
+
+	var plan Plan
+	for _, want := range desired {
+		have, exists := liveByName[want.ClassName]
+		if !exists {
+			plan.Changes = append(plan.Changes, Change{Kind: CreateClassChange, ClassName: want.ClassName})
+		}
+		for name, field := range want.Fields {
+			current, ok := have.Fields[name]
+			switch {
+			case !ok:
+				plan.Changes = append(plan.Changes, Change{Kind: AddFieldChange, ClassName: want.ClassName, FieldName: name, Field: field})
+			case !sameField(current, field):
+				plan.Changes = append(plan.Changes, Change{Kind: ChangeFieldChange, ClassName: want.ClassName, FieldName: name, Field: field, Current: current})
+			}
+		}
+		for name, current := range have.Fields {
+			if _, ok := want.Fields[name]; ok || defaultFields[name] || systemFields[want.ClassName][name] {
+				continue
+			}
+			plan.Changes = append(plan.Changes, Change{Kind: DeleteFieldChange, ClassName: want.ClassName, FieldName: name, Current: current})
+		}
+	}
+
+	// order by class, then class creation first, then by field
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		a, b := plan.Changes[i], plan.Changes[j]
+		if a.ClassName != b.ClassName {
+			return a.ClassName < b.ClassName
+		}
+		if (a.Kind == CreateClassChange) != (b.Kind == CreateClassChange) {
+			return a.Kind == CreateClassChange
+		}
+		return a.FieldName < b.FieldName
+	})
+	return plan
+}
+
+func sameField(a Field, b Field) bool {
+	return a.Type == b.Type && a.TargetClass == b.TargetClass && a.Required == b.Required &&
+		reflect.DeepEqual(a.DefaultValue, b.DefaultValue)
+}
+
+func (c *Object) PlanMigration(models ...interface{}) (Plan, *Error) {
+	desired, err := SchemasFromStructs(models...)
+	if err != nil {
+		return Plan{}, &Error{StatusCode: 500, Err: err}
+	}
+	live, lerr := c.ListSchemas()
+	if lerr != nil {
+		return Plan{}, lerr
+	}
+	return Diff(desired, live), nil
+}
+
+func (c *Object) ApplyMigration(plan Plan, allowDestructive bool) *Error {
+	// refuse the whole plan rather than applying part of it
+	if plan.Destructive() && !allowDestructive {
+		return &Error{
+			StatusCode: http.StatusConflict,
+			Err:        errors.New(destructiveChangesMessage),
+		}
+	}
+
+	// group the changes by class, keeping the plan order
+	var classNames []string
+	created := map[string]bool{}
+	added := map[string]map[string]Field{}
+	deleted := map[string][]string{}
+	for _, change := range plan.Changes {
+		if _, ok := added[change.ClassName]; !ok {
+			classNames = append(classNames, change.ClassName)
+			added[change.ClassName] = map[string]Field{}
+		}
+		switch change.Kind {
+		case CreateClassChange:
+			created[change.ClassName] = true
+		case AddFieldChange:
+			added[change.ClassName][change.FieldName] = change.Field
+		case DeleteFieldChange:
+			deleted[change.ClassName] = append(deleted[change.ClassName], change.FieldName)
+		case ChangeFieldChange:
+			deleted[change.ClassName] = append(deleted[change.ClassName], change.FieldName)
+			added[change.ClassName][change.FieldName] = change.Field
+		}
+	}
+
+	for _, className := range classNames {
+		if created[className] {
+			if _, err := c.CreateClass(className, added[className]); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(deleted[className]) > 0 {
+			if _, err := c.DeleteFields(className, deleted[className]...); err != nil {
+				return err
+			}
+		}
+		if len(added[className]) > 0 {
+			if _, err := c.AddFields(className, added[className]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
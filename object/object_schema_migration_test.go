@@ -0,0 +1,182 @@
+package object
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type post struct {
+	Title     string                 `parse:"title,required"`
+	Likes     int                    `parse:"likes,default=0"`
+	Published bool                   `parse:"published"`
+	Date      *time.Time             `parse:"date"`
+	Tags      []string               `parse:"tags"`
+	Meta      map[string]interface{} `parse:"meta"`
+	Author    string                 `parse:"author,pointer=_User"`
+	Fans      []string               `parse:"fans,relation=_User"`
+	Cover     string                 `parse:"cover,type=File"`
+	Ignored   string                 `parse:"-"`
+	Untagged  string
+}
+
+type comment struct {
+	Body string `parse:"body"`
+}
+
+func (comment) ClassName() string {
+	return "Comment"
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	schema, err := SchemaFromStruct(&post{})
+	assert.Nil(t, err)
+	assert.Equal(t, "post", schema.ClassName)
+	assert.Equal(t, map[string]Field{
+		"title":     {Type: StringField, Required: true},
+		"likes":     {Type: NumberField, DefaultValue: float64(0)},
+		"published": {Type: BooleanField},
+		"date":      {Type: DateField},
+		"tags":      {Type: ArrayField},
+		"meta":      {Type: ObjectField},
+		"author":    PointerTo("_User"),
+		"fans":      RelationTo("_User"),
+		"cover":     {Type: FileField},
+	}, schema.Fields)
+
+	schema, err = SchemaFromStruct(comment{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Comment", schema.ClassName)
+}
+
+func TestSchemaFromStructErrors(t *testing.T) {
+	_, err := SchemaFromStruct("post")
+	assert.EqualError(t, err, "model must be a struct, got string")
+
+	type unknownOption struct {
+		Title string `parse:"title,unique"`
+	}
+	_, err = SchemaFromStruct(unknownOption{})
+	assert.EqualError(t, err, `unknownOption: unknown option "unique" on field title`)
+
+	type untyped struct {
+		Value interface{} `parse:"value"`
+	}
+	_, err = SchemaFromStruct(untyped{})
+	assert.EqualError(t, err, "unable to infer parse type of field untyped.value, set it with type=")
+}
+
+func TestDiff(t *testing.T) {
+	desired := []ClassSchema{
+		{ClassName: "Comment", Fields: map[string]Field{"body": {Type: StringField}}},
+		{ClassName: "Post", Fields: map[string]Field{
+			"title": {Type: StringField, Required: true},
+			"likes": {Type: NumberField},
+			"tags":  {Type: ArrayField},
+		}},
+	}
+	live := []ClassSchema{
+		{ClassName: "Post", Fields: map[string]Field{
+			"objectId": {Type: StringField},
+			"ACL":      {Type: ACLField},
+			"title":    {Type: StringField, Required: true},
+			"likes":    {Type: StringField},
+			"old":      {Type: StringField},
+		}},
+		{ClassName: "Unmanaged", Fields: map[string]Field{"field": {Type: StringField}}},
+	}
+	plan := Diff(desired, live)
+	assert.True(t, plan.Destructive())
+	assert.Equal(t, `+ create class Comment
++ add field Comment.body String
+~ change field Post.likes String -> Number (destructive)
+- delete field Post.old String (destructive)
++ add field Post.tags Array`, plan.String())
+}
+
+func TestDiffFieldOptions(t *testing.T) {
+	desired := []ClassSchema{{ClassName: "Post", Fields: map[string]Field{
+		"title":  {Type: StringField, Required: true},
+		"likes":  {Type: NumberField, DefaultValue: float64(0)},
+		"status": {Type: StringField, DefaultValue: "draft"},
+	}}}
+	live := []ClassSchema{{ClassName: "Post", Fields: map[string]Field{
+		"title":  {Type: StringField},
+		"likes":  {Type: NumberField, DefaultValue: float64(1)},
+		"status": {Type: StringField, DefaultValue: "draft"},
+	}}}
+	assert.Equal(t, `~ change field Post.likes Number default=1 -> Number default=0 (destructive)
+~ change field Post.title String -> String required (destructive)`, Diff(desired, live).String())
+}
+
+func TestDiffSystemFields(t *testing.T) {
+	live := []ClassSchema{
+		{ClassName: "_Installation", Fields: map[string]Field{"deviceToken": {Type: StringField}, "channels": {Type: ArrayField}, "old": {Type: StringField}}},
+		{ClassName: "_Role", Fields: map[string]Field{"name": {Type: StringField}, "users": RelationTo("_User"), "roles": RelationTo("_Role")}},
+		{ClassName: "_Session", Fields: map[string]Field{"user": PointerTo("_User"), "sessionToken": {Type: StringField}, "expiresAt": {Type: DateField}}},
+		{ClassName: "_PushStatus", Fields: map[string]Field{"status": {Type: StringField}, "numSent": {Type: NumberField}}},
+	}
+	desired := []ClassSchema{{ClassName: "_Installation"}, {ClassName: "_Role"}, {ClassName: "_Session"}, {ClassName: "_PushStatus"}}
+	assert.Equal(t, "- delete field _Installation.old String (destructive)", Diff(desired, live).String())
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	schemas := []ClassSchema{{ClassName: "Post", Fields: map[string]Field{"author": PointerTo("_User")}}}
+	plan := Diff(schemas, schemas)
+	assert.True(t, plan.Empty())
+	assert.False(t, plan.Destructive())
+	assert.Equal(t, "no changes", plan.String())
+}
+
+func TestPlanMigration(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"className":"Comment","fields":{"objectId":{"type":"String"}}}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	plan, err := c.PlanMigration(comment{})
+	assert.Nil(t, err)
+	assert.Equal(t, "+ add field Comment.body String", plan.String())
+}
+
+func TestApplyMigration(t *testing.T) {
+	var requests []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		marshalled, _ := json.Marshal(body["fields"])
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(marshalled))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	plan := Plan{Changes: []Change{
+		{Kind: CreateClassChange, ClassName: "Comment"},
+		{Kind: AddFieldChange, ClassName: "Comment", FieldName: "body", Field: Field{Type: StringField}},
+		{Kind: ChangeFieldChange, ClassName: "Post", FieldName: "likes", Field: Field{Type: NumberField}, Current: Field{Type: StringField}},
+		{Kind: DeleteFieldChange, ClassName: "Post", FieldName: "old", Current: Field{Type: StringField}},
+	}}
+	err := c.ApplyMigration(plan, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`POST /schemas/Comment {"body":{"type":"String"}}`,
+		`PUT /schemas/Post {"likes":{"__op":"Delete"},"old":{"__op":"Delete"}}`,
+		`PUT /schemas/Post {"likes":{"type":"Number"}}`,
+	}, requests)
+}
+
+func TestApplyMigrationDestructive(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil, WithMasterKey("masterKey"))
+	plan := Plan{Changes: []Change{{Kind: DeleteFieldChange, ClassName: "Post", FieldName: "old"}}}
+	err := c.ApplyMigration(plan, false)
+	assert.Error(t, err)
+	assert.Equal(t, "migration plan contains destructive changes: 409", err.Error())
+}
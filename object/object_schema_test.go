@@ -0,0 +1,220 @@
+package object
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestListSchemas(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/schemas", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"className":"Post","fields":{"title":{"type":"String"},"author":{"type":"Pointer","targetClass":"_User"}},"indexes":{"_id_":{"_id":1},"title_likes":{"title":1,"likes":-1}}}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schemas, err := c.ListSchemas()
+	assert.Nil(t, err)
+	assert.Len(t, schemas, 1)
+	assert.Equal(t, "Post", schemas[0].ClassName)
+	assert.Equal(t, StringField, schemas[0].Fields["title"].Type)
+	assert.Equal(t, PointerTo("_User"), schemas[0].Fields["author"])
+	assert.Equal(t, Index{Ascending("title"), Descending("likes")}, schemas[0].Indexes["title_likes"])
+}
+
+func TestListSchemasMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil)
+	schemas, err := c.ListSchemas()
+	assert.Nil(t, schemas)
+	assert.Error(t, err)
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func TestGetSchema(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/schemas/Post", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"className":"Post","fields":{"likes":{"type":"Number","required":true,"defaultValue":0}}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.GetSchema("Post")
+	assert.Nil(t, err)
+	assert.Equal(t, Field{Type: NumberField, Required: true, DefaultValue: float64(0)}, schema.Fields["likes"])
+}
+
+func TestGetSchemaError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.GetSchema("Post")
+	assert.Nil(t, schema)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to get schema: 400", err.Error())
+}
+
+func TestGetSchemaHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":103, "error":"Class Post does not exist."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.GetSchema("Post")
+	assert.Nil(t, schema)
+	assert.Error(t, err)
+	assert.Equal(t, "Class Post does not exist.: 400", err.Error())
+}
+
+func TestCreateClass(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/schemas/Post", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"className":"Post","fields":{"title":{"type":"String","required":true},"author":{"type":"Pointer","targetClass":"_User"},"likes":{"type":"Relation","targetClass":"_User"},"published":{"type":"Boolean","defaultValue":false}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.CreateClass("Post", map[string]Field{
+		"title":     {Type: StringField, Required: true},
+		"author":    PointerTo("_User"),
+		"likes":     RelationTo("_User"),
+		"published": {Type: BooleanField, DefaultValue: false},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Post", schema.ClassName)
+}
+
+func TestAddFields(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"className":"Post","fields":{"tags":{"type":"Array"}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.AddFields("Post", map[string]Field{"tags": {Type: ArrayField}})
+	assert.Nil(t, err)
+	assert.Equal(t, ArrayField, schema.Fields["tags"].Type)
+}
+
+func TestDeleteFields(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"className":"Post","fields":{"tags":{"__op":"Delete"},"location":{"__op":"Delete"}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"className":"Post"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.DeleteFields("Post", "tags", "location")
+	assert.Nil(t, err)
+	assert.Equal(t, "Post", schema.ClassName)
+}
+
+func TestSetIndexes(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"className":"Post","indexes":{"title_likes":{"title":1,"likes":-1}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.SetIndexes("Post", map[string]Index{"title_likes": {Ascending("title"), Descending("likes")}})
+	assert.Nil(t, err)
+	assert.Equal(t, Index{Ascending("title"), Descending("likes")}, schema.Indexes["title_likes"])
+}
+
+func TestSetIndexesSpecialTypes(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"className":"Post","indexes":{"search":{"title":"text","location":"2dsphere","author":"hashed","likes":-1}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	index := Index{TextIndex("title"), GeoIndex("location"), HashedIndex("author"), Descending("likes")}
+	schema, err := c.SetIndexes("Post", map[string]Index{"search": index})
+	assert.Nil(t, err)
+	assert.Equal(t, index, schema.Indexes["search"])
+}
+
+func TestDeleteIndexes(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"className":"Post","indexes":{"title_likes":{"__op":"Delete"}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"className":"Post"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	_, err := c.DeleteIndexes("Post", "title_likes")
+	assert.Nil(t, err)
+}
+
+func TestUpdateSchemaHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":255, "error":"Field tags exists, cannot update."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	schema, err := c.AddFields("Post", map[string]Field{"tags": {Type: ArrayField}})
+	assert.Nil(t, schema)
+	assert.Error(t, err)
+	assert.Equal(t, "Field tags exists, cannot update.: 400", err.Error())
+}
+
+func TestDropClass(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/schemas/Post", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	isDropped, err := c.DropClass("Post")
+	assert.Nil(t, err)
+	assert.True(t, isDropped)
+}
+
+func TestDropClassError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	isDropped, err := c.DropClass("Post")
+	assert.False(t, isDropped)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to drop class: 400", err.Error())
+}
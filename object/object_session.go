@@ -0,0 +1,96 @@
+package object
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	unableToGetSessionMessage     = "unable to get session"
+	unableToListSessionsMessage   = "unable to list sessions"
+	unableToUpdateSessionMessage  = "unable to update session"
+	unableToDeleteSessionMessage  = "unable to delete session"
+	unableToUpgradeSessionMessage = "unable to upgrade to revocable session"
+)
+
+func (c *Object) CurrentSession() (map[string]interface{}, *Error) {
+	var result map[string]interface{}
+	if _, err := c.request("GET", "/sessions/me", nil, nil, sessionAuth, &result, unableToGetSessionMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Object) ListSessions(option ...ListOption) ([]map[string]interface{}, *Error) {
+	q, err := listQuery(option)
+	if err != nil {
+		return nil, err
+	}
+	return c.listSessions(q)
+}
+
+func (c *Object) listSessions(query url.Values) ([]map[string]interface{}, *Error) {
+	var result struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	if _, err := c.request("GET", "/sessions", query, nil, sessionAuth, &result, unableToListSessionsMessage); err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+func (c *Object) GetSession(sessionId string) (map[string]interface{}, *Error) {
+	var result map[string]interface{}
+	if _, err := c.request("GET", fmt.Sprintf("/sessions/%s", url.PathEscape(sessionId)), nil, nil, sessionAuth, &result, unableToGetSessionMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Object) UpdateSession(sessionId string, data map[string]interface{}) (bool, *Error) {
+	if _, err := c.request("PUT", fmt.Sprintf("/sessions/%s", url.PathEscape(sessionId)), nil, data, sessionAuth, nil, unableToUpdateSessionMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Object) DeleteSession(sessionId string) (bool, *Error) {
+	if _, err := c.request("DELETE", fmt.Sprintf("/sessions/%s", url.PathEscape(sessionId)), nil, nil, sessionAuth, nil, unableToDeleteSessionMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Object) DeleteUserSessions(userId string) (int, *Error) {
+	// only the master key can see the sessions of another user
+	if c.masterKey == "" {
+		return 0, &Error{
+			StatusCode: http.StatusForbidden,
+			Err:        errors.New(masterKeyRequiredMessage),
+		}
+	}
+	// find every session before deleting any, a deletion would shift the pages
+	sessions, err := listAllPages([]ListOption{WithWhere(map[string]interface{}{"user": pointer(userClassName, userId)})}, c.listSessions)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, session := range sessions {
+		id, _ := session["objectId"].(string)
+		if _, err := c.DeleteSession(id); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (c *Object) UpgradeToRevocableSession() (map[string]interface{}, *Error) {
+	var result map[string]interface{}
+	if _, err := c.request("POST", "/upgradeToRevocableSession", nil, nil, sessionAuth, &result, unableToUpgradeSessionMessage); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
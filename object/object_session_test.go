@@ -0,0 +1,205 @@
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func TestCurrentSession(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/sessions/me", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"sessionId","sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	session, err := c.CurrentSession()
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionId", session["objectId"])
+}
+
+func TestCurrentSessionHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":209, "error":"Invalid session token"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	session, err := c.CurrentSession()
+	assert.Nil(t, session)
+	assert.Error(t, err)
+	assert.Equal(t, "Invalid session token: 400", err.Error())
+	assert.Equal(t, float64(InvalidSessionToken), err.HostErrorCode)
+}
+
+func TestListSessions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sessions", r.URL.Path)
+		assert.Equal(t, "masterKey", r.Header.Get("X-Parse-Master-Key"))
+		assert.Empty(t, r.Header.Get("X-Parse-Session-Token"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"objectId":"a"},{"objectId":"b"}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	sessions, err := c.ListSessions(WithLimit(10))
+	assert.Nil(t, err)
+	assert.Len(t, sessions, 2)
+}
+
+func TestListSessionsError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	sessions, err := c.ListSessions()
+	assert.Nil(t, sessions)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to list sessions: 400", err.Error())
+}
+
+func TestGetSession(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sessions/sessionId", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"sessionId"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	session, err := c.GetSession("sessionId")
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionId", session["objectId"])
+}
+
+func TestUpdateSession(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/sessions/sessionId", r.URL.Path)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "mobile", body["device"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"updatedAt"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	isUpdated, err := c.UpdateSession("sessionId", map[string]interface{}{"device": "mobile"})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestDeleteSession(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/sessions/sessionId", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	isDeleted, err := c.DeleteSession("sessionId")
+	assert.Nil(t, err)
+	assert.True(t, isDeleted)
+}
+
+func TestDeleteSessionError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b)
+	isDeleted, err := c.DeleteSession("sessionId")
+	assert.False(t, isDeleted)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to delete session: 400", err.Error())
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	var deleted []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == "DELETE" {
+			deleted = append(deleted, r.URL.Path)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		assert.Equal(t, `{"user":{"__type":"Pointer","className":"_User","objectId":"userId"}}`, r.URL.Query().Get("where"))
+		_, _ = w.Write([]byte(`{"results":[{"objectId":"a"},{"objectId":"b"}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	count, err := c.DeleteUserSessions("userId")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"/sessions/a", "/sessions/b"}, deleted)
+}
+
+func TestDeleteUserSessionsPages(t *testing.T) {
+	deleted := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == "DELETE" {
+			deleted++
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		// every session is listed before the first is deleted
+		assert.Equal(t, 0, deleted)
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		results := make([]map[string]interface{}, 0, 1000)
+		for i := skip; i < 1500 && i < skip+1000; i++ {
+			results = append(results, map[string]interface{}{"objectId": fmt.Sprintf("session%d", i)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "", nil, b, WithMasterKey("masterKey"))
+	count, err := c.DeleteUserSessions("userId")
+	assert.Nil(t, err)
+	assert.Equal(t, 1500, count)
+	assert.Equal(t, 1500, deleted)
+}
+
+func TestDeleteUserSessionsMasterKeyRequired(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil)
+	count, err := c.DeleteUserSessions("userId")
+	assert.Equal(t, 0, count)
+	assert.Error(t, err)
+	assert.Equal(t, "master key is required: 403", err.Error())
+}
+
+func TestUpgradeToRevocableSession(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/upgradeToRevocableSession", r.URL.Path)
+		assert.Equal(t, "legacyToken", r.Header.Get("X-Parse-Session-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"sessionToken":"r:newToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "legacyToken", nil, b)
+	session, err := c.UpgradeToRevocableSession()
+	assert.Nil(t, err)
+	assert.Equal(t, "r:newToken", session["sessionToken"])
+}
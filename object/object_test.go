@@ -2,6 +2,9 @@ package object
 
 import (
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 )
 
@@ -20,3 +23,36 @@ func TestInitialize(t *testing.T) {
 	assert.Equal(t, c.sessionToken, "sessionToken")
 	assert.Equal(t, c.baseUrl.String(), "https://parseapi.back4app.com")
 }
+
+func TestNewObjectWithMasterKey(t *testing.T) {
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, nil, WithMasterKey("masterKey"))
+	assert.Equal(t, c.masterKey, "masterKey")
+}
+
+func TestPathSegmentsEscaped(t *testing.T) {
+	var paths []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	c := NewObject("applicationId", "restApiKey", "sessionToken", nil, b, WithMasterKey("masterKey"))
+	_, _ = c.CallFunction("a/b?c", nil)
+	_, _ = c.StartJob("a/b?c", nil)
+	_, _ = c.JobStatus("a/b?c")
+	_, _ = c.GetSchema("a/b?c")
+	_, _ = c.GetSession("a/b?c")
+	_, _ = c.GetInstallation("a/b?c")
+	_, _ = c.Read("a/b?c", "a/b?c")
+	assert.Equal(t, []string{
+		"/functions/a%2Fb%3Fc",
+		"/jobs/a%2Fb%3Fc",
+		"/classes/_JobStatus/a%2Fb%3Fc",
+		"/schemas/a%2Fb%3Fc",
+		"/sessions/a%2Fb%3Fc",
+		"/installations/a%2Fb%3Fc",
+		"/classes/a%2Fb%3Fc/a%2Fb%3Fc",
+	}, paths)
+}
@@ -14,7 +14,7 @@ const unableToUpdateObjectMessage = "unable to update object"
 
 func (c *Object) Update(className string, id string, data map[string]interface{}) (bool, *Error) {
 	// create the URL
-	updateUrl, _ := url.Parse(fmt.Sprintf("/classes/%s/%s", className, id))
+	updateUrl, _ := url.Parse(fmt.Sprintf("/classes/%s/%s", url.PathEscape(className), url.PathEscape(id)))
 	updateClassUrl := c.baseUrl.ResolveReference(updateUrl)
 
 	// create the body
@@ -25,10 +25,10 @@ func (c *Object) Update(className string, id string, data map[string]interface{}
 	req.Header.Add(contentTypeHeader, contentTypeValue)
 	req.Header.Add(applicationIdHeader, c.applicationId)
 	req.Header.Add(restApiKeyHeader, c.restApiKey)
-	req.Header.Add(sessionTokenHeader, c.sessionToken)
+	req.Header.Add(sessionTokenHeader, c.SessionToken())
 
 	// make the request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return false, &Error{StatusCode: 500, Err: err}
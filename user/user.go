@@ -1,19 +1,33 @@
 package user
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 const (
-	back4appBaseUrl     = "https://parseapi.back4app.com"
-	contentTypeHeader   = "Content-type"
-	contentTypeValue    = "application/json"
-	applicationIdHeader = "X-Parse-Application-Id"
-	restApiKeyHeader    = "X-Parse-REST-API-Key"
-	revocableHeader     = "X-Parse-Revocable-Session"
-	sessionTokenHeader  = "X-Parse-Session-Token"
+	back4appBaseUrl      = "https://parseapi.back4app.com"
+	contentTypeHeader    = "Content-type"
+	contentTypeValue     = "application/json"
+	applicationIdHeader  = "X-Parse-Application-Id"
+	restApiKeyHeader     = "X-Parse-REST-API-Key"
+	revocableHeader      = "X-Parse-Revocable-Session"
+	sessionTokenHeader   = "X-Parse-Session-Token"
+	installationIdHeader = "X-Parse-Installation-Id"
+)
+
+// Parse Server error codes reported in Error.HostErrorCode
+const (
+	InvalidSessionToken = 209
+)
+
+var (
+	ErrInvalidSessionToken = errors.New("invalid session token")
+	ErrNotLoggedIn         = errors.New("not logged in")
 )
 
 type Error struct {
@@ -26,12 +40,33 @@ func (r *Error) Error() string {
 	return fmt.Sprintf("%v: %d", r.Err, r.StatusCode)
 }
 
+func (r *Error) Unwrap() error {
+	return r.Err
+}
+
 type User struct {
 	client        *http.Client
 	baseUrl       *url.URL
 	applicationId string
 	restApiKey    string
-	Session       map[string]interface{}
+	store         SessionStore
+	storeMu       sync.Mutex
+	loadErr       error
+	mu            sync.RWMutex
+	session       map[string]interface{}
+
+	onInvalidSession InvalidSessionHandler
+	refresher        sessionRefresher
+}
+
+type Option func(*User)
+
+// WithSessionStore persists the session on login, sign up and logout, and
+// restores it when the user is created.
+func WithSessionStore(store SessionStore) Option {
+	return func(s *User) {
+		s.store = store
+	}
 }
 
 func getErrorMessage(error string, defaultError string) string {
@@ -41,7 +76,7 @@ func getErrorMessage(error string, defaultError string) string {
 	return error
 }
 
-func NewUser(applicationId string, restApiKey string, httpClient *http.Client, baseUrl *url.URL) *User {
+func NewUser(applicationId string, restApiKey string, httpClient *http.Client, baseUrl *url.URL, options ...Option) *User {
 	s := &User{
 		client:        httpClient,
 		baseUrl:       baseUrl,
@@ -54,5 +89,79 @@ func NewUser(applicationId string, restApiKey string, httpClient *http.Client, b
 	if s.baseUrl == nil {
 		s.baseUrl, _ = url.Parse(back4appBaseUrl)
 	}
+	for _, option := range options {
+		option(s)
+	}
+
+	// restore the session of a previous run
+	if s.store != nil {
+		session, err := s.store.Load()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+		s.session = session
+		s.loadErr = err
+	}
 	return s
 }
+
+// LoadError returns the error of the session store when the session was
+// restored, the user then starts without a session.
+func (s *User) LoadError() error {
+	return s.loadErr
+}
+
+func (s *User) ForSession(sessionToken string) *User {
+	// the derived user is never persisted, it would overwrite the stored session
+	u := NewUser(s.applicationId, s.restApiKey, s.client, s.baseUrl, WithOnInvalidSession(s.onInvalidSession))
+	u.setSession(map[string]interface{}{"sessionToken": sessionToken})
+	return u
+}
+
+func (s *User) SessionToken() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sessionToken, _ := s.session["sessionToken"].(string)
+	return sessionToken
+}
+
+func (s *User) CurrentSession() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.session == nil {
+		return nil
+	}
+	session := make(map[string]interface{}, len(s.session))
+	for k, v := range s.session {
+		session[k] = v
+	}
+	return session
+}
+
+func (s *User) setSession(session map[string]interface{}) {
+	// storeMu keeps the store writes in the order of the sessions, while
+	// readers of the session only wait for the swap
+	s.storeMu.Lock()
+	defer s.storeMu.Unlock()
+	s.mu.Lock()
+	s.session = session
+	s.mu.Unlock()
+
+	// a failing store keeps the session in memory for this run
+	if s.store == nil {
+		return
+	}
+	var err error
+	if session == nil {
+		err = s.store.Clear()
+	} else {
+		err = s.store.Save(session)
+	}
+	if err != nil {
+		log.Println("Error: ", err)
+	}
+}
+
+func (s *User) clearSession() {
+	s.setSession(nil)
+}
@@ -0,0 +1,211 @@
+package user
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+const (
+	unableToLoginWithAuthDataMessage = "unable to login with auth data"
+	unableToLinkAuthDataMessage      = "unable to link auth data"
+	unableToUnlinkAuthDataMessage    = "unable to unlink auth data"
+)
+
+const (
+	AppleProvider     = "apple"
+	GoogleProvider    = "google"
+	FacebookProvider  = "facebook"
+	AnonymousProvider = "anonymous"
+)
+
+type AppleAuthData struct {
+	Id    string `json:"id"`
+	Token string `json:"token"`
+}
+
+type GoogleAuthData struct {
+	Id          string `json:"id"`
+	IdToken     string `json:"id_token"`
+	AccessToken string `json:"access_token,omitempty"`
+}
+
+type FacebookAuthData struct {
+	Id             string `json:"id"`
+	AccessToken    string `json:"access_token"`
+	ExpirationDate string `json:"expiration_date,omitempty"`
+}
+
+type AnonymousAuthData struct {
+	Id string `json:"id"`
+}
+
+func (s *User) LoginWithAuthData(provider string, data interface{}) (*Profile, *Error) {
+	// create the URL
+	usersUrl, _ := url.Parse("/users")
+	createUserUrl := s.baseUrl.ResolveReference(usersUrl)
+
+	// create the body
+	marshalled, _ := json.Marshal(map[string]interface{}{
+		"authData": map[string]interface{}{provider: data},
+	})
+
+	// create the request
+	req, _ := http.NewRequest("POST", createUserUrl.String(), bytes.NewReader(marshalled))
+	req.Header.Add(contentTypeHeader, contentTypeValue)
+	req.Header.Add(applicationIdHeader, s.applicationId)
+	req.Header.Add(restApiKeyHeader, s.restApiKey)
+	req.Header.Add(revocableHeader, "1")
+
+	// Make the request
+	resp, err := s.do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return nil, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code, a new user is created and an existing user is logged in
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		// Parse the response
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result == nil || (result["error"] == nil && result["code"] == nil) {
+			return nil, &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(unableToLoginWithAuthDataMessage),
+			}
+		}
+		message := getErrorMessage(result["error"].(string), unableToLoginWithAuthDataMessage)
+		return nil, &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: result["code"].(float64),
+			Err:           errors.New(message),
+		}
+	}
+
+	// Parse the response
+	var result map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+
+	// Save the session
+	s.setSession(result)
+
+	return newProfile(result), nil
+}
+
+func (s *User) LoginAnonymously() (*Profile, *Error) {
+	id, err := newUUID()
+	if err != nil {
+		return nil, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	return s.LoginWithAuthData(AnonymousProvider, AnonymousAuthData{Id: id})
+}
+
+func (s *User) LinkAuthData(provider string, data interface{}) *Error {
+	return s.updateAuthData(provider, data, unableToLinkAuthDataMessage)
+}
+
+func (s *User) UnlinkAuthData(provider string) *Error {
+	return s.updateAuthData(provider, nil, unableToUnlinkAuthDataMessage)
+}
+
+func (s *User) updateAuthData(provider string, data interface{}, defaultError string) *Error {
+	// get the logged in user
+	session := s.CurrentSession()
+	sessionToken, _ := session["sessionToken"].(string)
+	userId, _ := session["objectId"].(string)
+	if sessionToken == "" || userId == "" {
+		return &Error{
+			StatusCode: http.StatusUnauthorized,
+			Err:        ErrNotLoggedIn,
+		}
+	}
+
+	// create the URL
+	userUrl, _ := url.Parse(fmt.Sprintf("/users/%s", url.PathEscape(userId)))
+	updateUserUrl := s.baseUrl.ResolveReference(userUrl)
+
+	// create the body, a null provider unlinks it
+	marshalled, _ := json.Marshal(map[string]interface{}{
+		"authData": map[string]interface{}{provider: data},
+	})
+
+	// create the request
+	req, _ := http.NewRequest("PUT", updateUserUrl.String(), bytes.NewReader(marshalled))
+	req.Header.Add(contentTypeHeader, contentTypeValue)
+	req.Header.Add(applicationIdHeader, s.applicationId)
+	req.Header.Add(restApiKeyHeader, s.restApiKey)
+	req.Header.Add(sessionTokenHeader, sessionToken)
+
+	// Make the request
+	resp, err := s.do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code
+	if resp.StatusCode != http.StatusOK {
+		// Parse the response
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result == nil || (result["error"] == nil && result["code"] == nil) {
+			return &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(defaultError),
+			}
+		}
+		message := getErrorMessage(result["error"].(string), defaultError)
+		return &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: result["code"].(float64),
+			Err:           errors.New(message),
+		}
+	}
+
+	return nil
+}
+
+func newUUID() (string, error) {
+	// a random version 4 UUID
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
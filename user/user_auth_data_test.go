@@ -0,0 +1,132 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestLoginWithAuthData(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/users", r.URL.Path)
+		assert.Equal(t, "1", r.Header.Get("X-Parse-Revocable-Session"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"authData":{"apple":{"id":"appleId","token":"identityToken"}}}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"userId","sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.LoginWithAuthData(AppleProvider, AppleAuthData{Id: "appleId", Token: "identityToken"})
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", u.SessionToken)
+	assert.Equal(t, "sessionToken", s.SessionToken())
+}
+
+func TestLoginWithAuthDataExistingUser(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"authData":{"google":{"id":"googleId","id_token":"idToken"}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"userId","sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.LoginWithAuthData(GoogleProvider, GoogleAuthData{Id: "googleId", IdToken: "idToken"})
+	assert.Nil(t, err)
+	assert.Equal(t, "userId", u.ObjectID)
+}
+
+func TestLoginWithAuthDataHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":252,"error":"This authentication method is unsupported."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.LoginWithAuthData(FacebookProvider, FacebookAuthData{Id: "facebookId", AccessToken: "accessToken"})
+	assert.Nil(t, u)
+	assert.Error(t, err)
+	assert.Equal(t, "This authentication method is unsupported.: 400", err.Error())
+	assert.Nil(t, s.CurrentSession())
+}
+
+func TestLoginAnonymously(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		id := body["authData"]["anonymous"]["id"]
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"userId","sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.LoginAnonymously()
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", u.SessionToken)
+}
+
+func TestLinkAuthData(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/users/userId", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"authData":{"facebook":{"id":"facebookId","access_token":"accessToken"}}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"updatedAt"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	err := s.LinkAuthData(FacebookProvider, FacebookAuthData{Id: "facebookId", AccessToken: "accessToken"})
+	assert.Nil(t, err)
+}
+
+func TestUnlinkAuthData(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"authData":{"facebook":null}}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"updatedAt"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	err := s.UnlinkAuthData(FacebookProvider)
+	assert.Nil(t, err)
+}
+
+func TestUnlinkAuthDataNotLoggedIn(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	err := s.UnlinkAuthData(FacebookProvider)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotLoggedIn))
+}
+
+func TestLinkAuthDataError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	err := s.LinkAuthData(AppleProvider, AppleAuthData{Id: "appleId", Token: "token"})
+	assert.Error(t, err)
+	assert.Equal(t, "unable to link auth data: 400", err.Error())
+}
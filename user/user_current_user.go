@@ -11,7 +11,7 @@ import (
 
 const unableToGetCurrentUserMessage = "unable to get current user"
 
-func (s *User) CurrentUser(sessionToken string) (map[string]interface{}, *Error) {
+func (s *User) CurrentUser(sessionToken string) (*Profile, *Error) {
 	// Create the URL with the parameters
 	userUrl, _ := url.Parse("/users/me")
 	joinedUrl := s.baseUrl.ResolveReference(userUrl)
@@ -24,7 +24,7 @@ func (s *User) CurrentUser(sessionToken string) (map[string]interface{}, *Error)
 	req.Header.Add(sessionTokenHeader, sessionToken)
 
 	// Make the request
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return nil, &Error{
@@ -68,5 +68,5 @@ func (s *User) CurrentUser(sessionToken string) (map[string]interface{}, *Error)
 		}
 	}
 
-	return result, nil
+	return newProfile(result), nil
 }
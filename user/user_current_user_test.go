@@ -18,7 +18,7 @@ func TestCurrentUser(t *testing.T) {
 	s := NewUser("applicationId", "restApiKey", nil, b)
 	item, _ := s.CurrentUser("sessionToken")
 	assert.NotNil(t, item)
-	assert.Equal(t, "item", item["item"])
+	assert.Equal(t, "item", item.Extra["item"])
 }
 
 func TestCurrentUserError(t *testing.T) {
@@ -29,7 +29,7 @@ func TestCurrentUserError(t *testing.T) {
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
 	u, err := s.CurrentUser("sessionToken")
-	assert.Equal(t, map[string]interface{}(nil), u)
+	assert.Nil(t, u)
 	assert.Error(t, err)
 	assert.Equal(t, "unable to get current user: 400", err.Error())
 }
@@ -43,7 +43,7 @@ func TestCurrentUserHostError(t *testing.T) {
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
 	u, err := s.CurrentUser("sessionToken")
-	assert.Equal(t, map[string]interface{}(nil), u)
+	assert.Nil(t, u)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid login parameters: 400", err.Error())
 }
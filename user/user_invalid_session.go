@@ -0,0 +1,124 @@
+package user
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// InvalidSessionHandler returns a new session token to retry a request
+// rejected with InvalidSessionToken, or an empty token to skip the retry.
+type InvalidSessionHandler func(sessionToken string) (string, error)
+
+func WithOnInvalidSession(handler InvalidSessionHandler) Option {
+	return func(s *User) {
+		s.onInvalidSession = handler
+	}
+}
+
+func (s *User) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil || s.onInvalidSession == nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+	sessionToken := req.Header.Get(sessionTokenHeader)
+	if sessionToken == "" {
+		return resp, nil
+	}
+
+	// peek at the error code, the caller still reads the body
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var result struct {
+		Code float64 `json:"code"`
+	}
+	if json.Unmarshal(body, &result) != nil || result.Code != InvalidSessionToken {
+		return resp, nil
+	}
+
+	newSessionToken := s.refreshSession(sessionToken)
+	if newSessionToken == "" {
+		return resp, nil
+	}
+
+	// retry once with the new token
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	retry.Header.Set(sessionTokenHeader, newSessionToken)
+	return s.client.Do(retry)
+}
+
+func (s *User) refreshSession(staleToken string) string {
+	return s.refresher.refresh(staleToken, s.onInvalidSession, func(newSessionToken string) {
+		// a handler logging in again through this user has already replaced the session
+		session := s.CurrentSession()
+		if session["sessionToken"] != staleToken {
+			return
+		}
+		session["sessionToken"] = newSessionToken
+		s.setSession(session)
+	})
+}
+
+// sessionRefresher matches the one in the object package.
+type sessionRefresher struct {
+	mu             sync.Mutex
+	inFlight       map[string]*sessionRefresh
+	staleToken     string
+	refreshedToken string
+}
+
+type sessionRefresh struct {
+	done  chan struct{}
+	token string
+}
+
+func (r *sessionRefresher) refresh(staleToken string, handler InvalidSessionHandler, refreshed func(string)) string {
+	r.mu.Lock()
+	if staleToken == r.staleToken {
+		refreshedToken := r.refreshedToken
+		r.mu.Unlock()
+		return refreshedToken
+	}
+	if call, ok := r.inFlight[staleToken]; ok {
+		r.mu.Unlock()
+		<-call.done
+		return call.token
+	}
+	if r.inFlight == nil {
+		r.inFlight = map[string]*sessionRefresh{}
+	}
+	call := &sessionRefresh{done: make(chan struct{})}
+	r.inFlight[staleToken] = call
+	r.mu.Unlock()
+
+	newSessionToken, err := handler(staleToken)
+	if err != nil {
+		log.Println("Error: ", err)
+		newSessionToken = ""
+	} else if newSessionToken != "" {
+		refreshed(newSessionToken)
+	}
+
+	r.mu.Lock()
+	delete(r.inFlight, staleToken)
+	if err == nil {
+		r.staleToken = staleToken
+		r.refreshedToken = newSessionToken
+	}
+	call.token = newSessionToken
+	r.mu.Unlock()
+	close(call.done)
+	return newSessionToken
+}
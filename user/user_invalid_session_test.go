@@ -0,0 +1,159 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func invalidSessionServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"objectId":"userId","sessionToken":"newSessionToken"}`))
+			return
+		}
+		if r.Header.Get("X-Parse-Session-Token") != "newSessionToken" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":209, "error":"Invalid session token"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == "PUT" {
+			assert.JSONEq(t, `{"email":"new@example.com"}`, string(body))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"updatedAt"}`))
+	}))
+}
+
+func TestOnInvalidSessionLoginAndRetry(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	var s *User
+	s = NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		assert.Equal(t, "sessionToken", sessionToken)
+		if _, err := s.Login("username", "password"); err != nil {
+			return "", err
+		}
+		return s.SessionToken(), nil
+	}))
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	isUpdated, err := s.UpdateUser("userId", map[string]interface{}{"email": "new@example.com"})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+	assert.Equal(t, "newSessionToken", s.SessionToken())
+	assert.Equal(t, "userId", s.CurrentSession()["objectId"])
+}
+
+func TestOnInvalidSessionNotifyOnly(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	calls := 0
+	s := NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		calls++
+		return "", nil
+	}))
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	isUpdated, err := s.UpdateUser("userId", map[string]interface{}{"email": "new@example.com"})
+	assert.False(t, isUpdated)
+	assert.Equal(t, float64(InvalidSessionToken), err.HostErrorCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestOnInvalidSessionHandlerError(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		return "", errors.New("unable to login")
+	}))
+	user, err := s.CurrentUser("sessionToken")
+	assert.Nil(t, user)
+	assert.Equal(t, "Invalid session token: 400", err.Error())
+}
+
+func TestOnInvalidSessionForSession(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		return "newSessionToken", nil
+	}))
+	u := s.ForSession("sessionToken")
+	_, err := u.GetUser("userId")
+	assert.Nil(t, err)
+	assert.Equal(t, "newSessionToken", u.SessionToken())
+	assert.Empty(t, s.SessionToken())
+}
+
+func TestOnInvalidSessionNotCalledOnLogout(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		t.Fail()
+		return "", nil
+	}))
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	err := s.Logout()
+	assert.ErrorIs(t, err, ErrInvalidSessionToken)
+}
+
+func TestOnInvalidSessionKeepsSession(t *testing.T) {
+	svr := invalidSessionServer(t)
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		return "newSessionToken", nil
+	}))
+	s.setSession(map[string]interface{}{"objectId": "userId", "username": "username", "sessionToken": "sessionToken"})
+	_, err := s.GetUser("userId")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"objectId": "userId", "username": "username", "sessionToken": "newSessionToken"}, s.CurrentSession())
+}
+
+func TestOnInvalidSessionNestedRefresh(t *testing.T) {
+	// the first login answers a token that is already rejected
+	logins := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			logins++
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"objectId":"userId","sessionToken":"token%d"}`, logins)))
+			return
+		}
+		if r.Header.Get("X-Parse-Session-Token") != "token2" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":209, "error":"Invalid session token"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"userId"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	var s *User
+	s = NewUser("applicationId", "restApiKey", nil, b, WithOnInvalidSession(func(sessionToken string) (string, error) {
+		if _, err := s.Login("username", "password"); err != nil {
+			return "", err
+		}
+		// check the new session, which is rejected again the first time
+		if _, err := s.GetUser("userId"); err != nil {
+			return "", err
+		}
+		return s.SessionToken(), nil
+	}))
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	_, err := s.GetUser("userId")
+	assert.Nil(t, err)
+	assert.Equal(t, "token2", s.SessionToken())
+	assert.Equal(t, 2, logins)
+}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
@@ -11,26 +12,57 @@ import (
 
 const unableToLoginMessage = "unable to login"
 
-func (s *User) Login(username string, password string) (map[string]interface{}, *Error) {
-	// Define the parameters
-	params := url.Values{}
-	params.Add("username", username)
-	params.Add("password", password)
+type LoginOption func(*loginOptions)
 
-	// Create the URL with the parameters
+type loginOptions struct {
+	authData       map[string]interface{}
+	installationId string
+}
+
+func WithAuthData(authData map[string]interface{}) LoginOption {
+	return func(o *loginOptions) {
+		o.authData = authData
+	}
+}
+
+func WithInstallationId(installationId string) LoginOption {
+	return func(o *loginOptions) {
+		o.installationId = installationId
+	}
+}
+
+func (s *User) Login(username string, password string, options ...LoginOption) (*Profile, *Error) {
+	opts := &loginOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	// create the URL
 	loginUrl, _ := url.Parse("/login")
 	joinedUrl := s.baseUrl.ResolveReference(loginUrl)
-	joinedUrl.RawQuery = params.Encode()
+
+	// create the body, credentials are never sent in the URL
+	data := map[string]interface{}{
+		"username": username,
+		"password": password,
+	}
+	if opts.authData != nil {
+		data["authData"] = opts.authData
+	}
+	marshalled, _ := json.Marshal(data)
 
 	// create the request
-	req, _ := http.NewRequest("GET", joinedUrl.String(), nil)
+	req, _ := http.NewRequest("POST", joinedUrl.String(), bytes.NewReader(marshalled))
 	req.Header.Add(contentTypeHeader, contentTypeValue)
 	req.Header.Add(applicationIdHeader, s.applicationId)
 	req.Header.Add(restApiKeyHeader, s.restApiKey)
 	req.Header.Add(revocableHeader, "1")
+	if opts.installationId != "" {
+		req.Header.Add(installationIdHeader, opts.installationId)
+	}
 
 	// Make the request
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return nil, &Error{
@@ -75,7 +107,7 @@ func (s *User) Login(username string, password string) (map[string]interface{},
 	}
 
 	// Save the session
-	s.Session = result
+	s.setSession(result)
 
-	return result, nil
+	return newProfile(result), nil
 }
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -17,8 +18,52 @@ func TestLogin(t *testing.T) {
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
 	u, _ := s.Login("username", "password")
-	assert.NotEmptyf(t, u["sessionToken"], "Expected sessionToken to be initialized")
-	assert.NotEmptyf(t, s.Session["sessionToken"], "Expected sessionToken to be initialized")
+	assert.NotEmptyf(t, u.SessionToken, "Expected sessionToken to be initialized")
+	assert.NotEmptyf(t, s.SessionToken(), "Expected sessionToken to be initialized")
+}
+
+func TestLoginCredentialsInBody(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/login", r.URL.Path)
+		assert.Empty(t, r.URL.RawQuery)
+		assert.NotContains(t, r.URL.String(), "username")
+		assert.NotContains(t, r.URL.String(), "secret")
+		assert.Equal(t, "1", r.Header.Get("X-Parse-Revocable-Session"))
+		assert.Empty(t, r.Header.Get("X-Parse-Installation-Id"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"username": "username", "password": "secret"}, body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.Login("username", "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", u.SessionToken)
+}
+
+func TestLoginWithOptions(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.RawQuery)
+		assert.Equal(t, "installationId", r.Header.Get("X-Parse-Installation-Id"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"mfa": map[string]interface{}{"token": "123456"}}, body["authData"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.Login("username", "secret",
+		WithAuthData(map[string]interface{}{"mfa": map[string]interface{}{"token": "123456"}}),
+		WithInstallationId("installationId"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", u.SessionToken)
 }
 
 func TestLoginError(t *testing.T) {
@@ -29,7 +74,7 @@ func TestLoginError(t *testing.T) {
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
 	u, err := s.Login("username", "password")
-	assert.Equal(t, map[string]interface{}(nil), u)
+	assert.Nil(t, u)
 	assert.Error(t, err)
 	assert.Equal(t, "unable to login: 400", err.Error())
 }
@@ -43,7 +88,7 @@ func TestLoginHostError(t *testing.T) {
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
 	u, err := s.Login("username", "password")
-	assert.Equal(t, map[string]interface{}(nil), u)
+	assert.Nil(t, u)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid login parameters: 400", err.Error())
 }
@@ -0,0 +1,84 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+const unableToLogoutMessage = "unable to logout"
+
+func (s *User) Logout() *Error {
+	// get the session token
+	sessionToken := s.SessionToken()
+	if sessionToken == "" {
+		return &Error{
+			StatusCode: http.StatusUnauthorized,
+			Err:        ErrNotLoggedIn,
+		}
+	}
+
+	// create the URL
+	logoutUrl, _ := url.Parse("/logout")
+	joinedUrl := s.baseUrl.ResolveReference(logoutUrl)
+
+	// create the request
+	req, _ := http.NewRequest("POST", joinedUrl.String(), nil)
+	req.Header.Add(contentTypeHeader, contentTypeValue)
+	req.Header.Add(applicationIdHeader, s.applicationId)
+	req.Header.Add(restApiKeyHeader, s.restApiKey)
+	req.Header.Add(sessionTokenHeader, sessionToken)
+
+	// Make the request, an invalid session is not refreshed just to end it
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code
+	if resp.StatusCode != http.StatusOK {
+		// Parse the response
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result == nil || (result["error"] == nil && result["code"] == nil) {
+			return &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(unableToLogoutMessage),
+			}
+		}
+
+		// the session is gone on the server either way
+		if result["code"] == float64(InvalidSessionToken) {
+			s.clearSession()
+			return &Error{
+				StatusCode:    resp.StatusCode,
+				HostErrorCode: InvalidSessionToken,
+				Err:           ErrInvalidSessionToken,
+			}
+		}
+		message := getErrorMessage(result["error"].(string), unableToLogoutMessage)
+		return &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: result["code"].(float64),
+			Err:           errors.New(message),
+		}
+	}
+
+	// Clear the session
+	s.clearSession()
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestLogout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/logout", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	err := s.Logout()
+	assert.Nil(t, err)
+	assert.Nil(t, s.CurrentSession())
+}
+
+func TestLogoutNotLoggedIn(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	err := s.Logout()
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotLoggedIn))
+	assert.Equal(t, "not logged in: 401", err.Error())
+}
+
+func TestLogoutInvalidSessionToken(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":209,"error":"Invalid session token"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	err := s.Logout()
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidSessionToken))
+	assert.Equal(t, float64(InvalidSessionToken), err.HostErrorCode)
+	assert.Equal(t, "invalid session token: 400", err.Error())
+	assert.Nil(t, s.CurrentSession())
+}
+
+func TestLogoutError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	err := s.Logout()
+	assert.Error(t, err)
+	assert.Equal(t, "unable to logout: 400", err.Error())
+	assert.NotNil(t, s.CurrentSession())
+}
+
+func TestLogoutHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":1,"error":"error"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	err := s.Logout()
+	assert.Error(t, err)
+	assert.Equal(t, "error: 400", err.Error())
+}
@@ -0,0 +1,107 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	MFAProvider = "mfa"
+
+	unableToEnrollMFAMessage  = "unable to enroll mfa"
+	unableToDisableMFAMessage = "unable to disable mfa"
+)
+
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollMFA creates a TOTP secret for the logged in user, to show as a QR code
+// of its URI. It is only stored on the server by ConfirmMFA.
+func (s *User) EnrollMFA(issuer string) (*MFAEnrollment, *Error) {
+	session := s.CurrentSession()
+	if sessionToken, _ := session["sessionToken"].(string); sessionToken == "" {
+		return nil, &Error{
+			StatusCode: http.StatusUnauthorized,
+			Err:        ErrNotLoggedIn,
+		}
+	}
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		return nil, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+
+	// label the code with the username, or the id of users without one
+	account, _ := session["username"].(string)
+	if account == "" {
+		account, _ = session["objectId"].(string)
+	}
+	return &MFAEnrollment{Secret: secret, URI: TOTPURI(secret, issuer, account)}, nil
+}
+
+// ConfirmMFA enables MFA with the secret from EnrollMFA and a token generated
+// from it, and returns the recovery codes that can replace a token once.
+func (s *User) ConfirmMFA(secret string, token string) ([]string, *Error) {
+	session := s.CurrentSession()
+	sessionToken, _ := session["sessionToken"].(string)
+	userId, _ := session["objectId"].(string)
+	if sessionToken == "" || userId == "" {
+		return nil, &Error{
+			StatusCode: http.StatusUnauthorized,
+			Err:        ErrNotLoggedIn,
+		}
+	}
+
+	body := map[string]interface{}{
+		"authData": map[string]interface{}{
+			MFAProvider: map[string]string{"secret": secret, "token": token},
+		},
+	}
+	var result struct {
+		AuthDataResponse map[string]struct {
+			Recovery interface{} `json:"recovery"`
+		} `json:"authDataResponse"`
+	}
+	if err := s.users("PUT", fmt.Sprintf("/users/%s", url.PathEscape(userId)), nil, body, sessionToken, &result, unableToEnrollMFAMessage); err != nil {
+		return nil, err
+	}
+
+	// recovery codes come as a comma separated string or a list
+	var recovery []string
+	switch codes := result.AuthDataResponse[MFAProvider].Recovery.(type) {
+	case string:
+		for _, code := range strings.Split(codes, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				recovery = append(recovery, code)
+			}
+		}
+	case []interface{}:
+		for _, code := range codes {
+			if code, ok := code.(string); ok {
+				recovery = append(recovery, code)
+			}
+		}
+	}
+	return recovery, nil
+}
+
+// LoginWithMFA logs in a user with MFA enabled, the token is the current TOTP
+// code or a recovery code.
+func (s *User) LoginWithMFA(username string, password string, token string, options ...LoginOption) (*Profile, *Error) {
+	authData := map[string]interface{}{
+		MFAProvider: map[string]string{"token": token},
+	}
+	// copy the options so the caller's slice is never appended to
+	options = append(options[:len(options):len(options)], WithAuthData(authData))
+	return s.Login(username, password, options...)
+}
+
+func (s *User) DisableMFA() *Error {
+	return s.updateAuthData(MFAProvider, nil, unableToDisableMFAMessage)
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestEnrollMFA(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	s.setSession(map[string]interface{}{"objectId": "userId", "username": "username", "sessionToken": "sessionToken"})
+	enrollment, err := s.EnrollMFA("My App")
+	assert.Nil(t, err)
+	assert.Len(t, enrollment.Secret, 32)
+	uri, _ := url.Parse(enrollment.URI)
+	assert.Equal(t, "/My App:username", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+}
+
+func TestEnrollMFANotLoggedIn(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	enrollment, err := s.EnrollMFA("My App")
+	assert.Nil(t, enrollment)
+	assert.ErrorIs(t, err, ErrNotLoggedIn)
+}
+
+func TestConfirmMFA(t *testing.T) {
+	secret, _ := NewTOTPSecret()
+	token, _ := GenerateTOTP(secret, time.Now())
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/users/userId", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		var body map[string]map[string]map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]string{"secret": secret, "token": token}, body["authData"]["mfa"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"2023-01-01T00:00:00.000Z","authDataResponse":{"mfa":{"recovery":"first, second"}}}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	recovery, err := s.ConfirmMFA(secret, token)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, recovery)
+}
+
+func TestConfirmMFAHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":142,"error":"Invalid MFA token"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	recovery, err := s.ConfirmMFA("SECRET", "000000")
+	assert.Nil(t, recovery)
+	assert.Equal(t, "Invalid MFA token: 400", err.Error())
+}
+
+func TestLoginWithMFA(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/login", r.URL.Path)
+		assert.Equal(t, "installationId", r.Header.Get("X-Parse-Installation-Id"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "username", body["username"])
+		assert.Equal(t, map[string]interface{}{"mfa": map[string]interface{}{"token": "123456"}}, body["authData"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"userId","sessionToken":"sessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.LoginWithMFA("username", "password", "123456", WithInstallationId("installationId"))
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", u.SessionToken)
+}
+
+func TestDisableMFA(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"mfa": nil}, body["authData"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	assert.Nil(t, s.DisableMFA())
+}
@@ -0,0 +1,99 @@
+package user
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// fields of a user record that have their own Profile field
+var profileFields = map[string]bool{
+	"objectId":      true,
+	"username":      true,
+	"email":         true,
+	"emailVerified": true,
+	"sessionToken":  true,
+	"createdAt":     true,
+	"updatedAt":     true,
+	"authData":      true,
+}
+
+type Profile struct {
+	ObjectID      string
+	Username      string
+	Email         string
+	EmailVerified bool
+	SessionToken  string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	AuthData      map[string]interface{}
+	// Extra holds the custom fields of the user class
+	Extra map[string]interface{}
+
+	raw map[string]interface{}
+}
+
+func newProfile(raw map[string]interface{}) *Profile {
+	p := &Profile{raw: raw, Extra: map[string]interface{}{}}
+	p.ObjectID, _ = raw["objectId"].(string)
+	p.Username, _ = raw["username"].(string)
+	p.Email, _ = raw["email"].(string)
+	p.EmailVerified, _ = raw["emailVerified"].(bool)
+	p.SessionToken, _ = raw["sessionToken"].(string)
+	p.CreatedAt = parseDate(raw["createdAt"])
+	p.UpdatedAt = parseDate(raw["updatedAt"])
+	p.AuthData, _ = raw["authData"].(map[string]interface{})
+	for k, v := range raw {
+		if !profileFields[k] {
+			p.Extra[k] = v
+		}
+	}
+	return p
+}
+
+// parseDate reads both the ISO string Parse Server returns for createdAt and
+// updatedAt, and the {"__type":"Date","iso":...} form of custom date fields.
+func parseDate(v interface{}) time.Time {
+	if date, ok := v.(map[string]interface{}); ok {
+		v = date["iso"]
+	}
+	s, _ := v.(string)
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// Raw returns a copy of the user record as returned by Parse Server.
+func (p *Profile) Raw() map[string]interface{} {
+	raw := make(map[string]interface{}, len(p.raw))
+	for k, v := range p.raw {
+		raw[k] = v
+	}
+	return raw
+}
+
+// Decode decodes the user record into a struct with json tags, for typed
+// access to custom fields.
+func (p *Profile) Decode(out interface{}) error {
+	marshalled, err := json.Marshal(p.raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(marshalled, out)
+}
+
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = *newProfile(raw)
+	return nil
+}
+
+// MarshalJSON encodes the record as returned by Parse Server, changes to the
+// typed fields are not included.
+func (p *Profile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.raw)
+}
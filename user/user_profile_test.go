@@ -0,0 +1,64 @@
+package user
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestProfile(t *testing.T) {
+	var p Profile
+	err := json.Unmarshal([]byte(`{
+		"objectId":"userId",
+		"username":"username",
+		"email":"user@example.com",
+		"emailVerified":true,
+		"sessionToken":"sessionToken",
+		"createdAt":"2023-01-02T03:04:05.678Z",
+		"updatedAt":"2023-02-02T03:04:05.678Z",
+		"authData":{"anonymous":{"id":"id"}},
+		"nickname":"nick",
+		"birthday":{"__type":"Date","iso":"2000-01-01T00:00:00.000Z"}
+	}`), &p)
+	assert.Nil(t, err)
+	assert.Equal(t, "userId", p.ObjectID)
+	assert.Equal(t, "username", p.Username)
+	assert.Equal(t, "user@example.com", p.Email)
+	assert.True(t, p.EmailVerified)
+	assert.Equal(t, "sessionToken", p.SessionToken)
+	assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 678000000, time.UTC), p.CreatedAt)
+	assert.Equal(t, time.Date(2023, 2, 2, 3, 4, 5, 678000000, time.UTC), p.UpdatedAt)
+	assert.Equal(t, map[string]interface{}{"id": "id"}, p.AuthData["anonymous"])
+	assert.Len(t, p.Extra, 2)
+	assert.Equal(t, "nick", p.Extra["nickname"])
+	assert.Equal(t, "nick", p.Raw()["nickname"])
+	assert.Equal(t, "userId", p.Raw()["objectId"])
+
+	var custom struct {
+		Nickname string `json:"nickname"`
+		Birthday struct {
+			Iso string `json:"iso"`
+		} `json:"birthday"`
+	}
+	assert.Nil(t, p.Decode(&custom))
+	assert.Equal(t, "nick", custom.Nickname)
+	assert.Equal(t, "2000-01-01T00:00:00.000Z", custom.Birthday.Iso)
+
+	marshalled, _ := json.Marshal(&p)
+	var roundTrip Profile
+	assert.Nil(t, json.Unmarshal(marshalled, &roundTrip))
+	assert.Equal(t, p, roundTrip)
+}
+
+func TestProfileRawIsCopy(t *testing.T) {
+	p := newProfile(map[string]interface{}{"objectId": "userId"})
+	p.Raw()["objectId"] = "changed"
+	assert.Equal(t, "userId", p.Raw()["objectId"])
+}
+
+func TestProfileInvalidDate(t *testing.T) {
+	p := newProfile(map[string]interface{}{"createdAt": "yesterday"})
+	assert.True(t, p.CreatedAt.IsZero())
+	assert.Equal(t, "yesterday", p.Raw()["createdAt"])
+}
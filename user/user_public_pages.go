@@ -0,0 +1,190 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	unableToVerifyEmailMessage   = "unable to verify email"
+	unableToCheckResetMessage    = "unable to check password reset link"
+	unableToResetPasswordMessage = "unable to reset password"
+	formContentTypeValue         = "application/x-www-form-urlencoded"
+	requestedWithHeader          = "X-Requested-With"
+)
+
+var ErrInvalidLink = errors.New("invalid or expired link")
+
+// linkPages are the pages Parse Server redirects a link to, both the legacy
+// public pages and the ones of its pages router
+type linkPages struct {
+	success []string
+	invalid []string
+}
+
+var verifyEmailPages = linkPages{
+	success: []string{"verify_email_success.html", "email_verification_success.html"},
+	invalid: []string{"invalid_link.html", "invalid_verification_link.html", "email_verification_link_invalid.html",
+		"email_verification_link_expired.html"},
+}
+
+var passwordResetPages = linkPages{
+	success: []string{"choose_password", "password_reset.html"},
+	invalid: []string{"invalid_link.html", "password_reset_link_invalid.html"},
+}
+
+// VerifyEmail confirms an email address with the token from the verification
+// email, as the link in the email would.
+func (s *User) VerifyEmail(username string, token string) *Error {
+	q := url.Values{}
+	q.Set("token", token)
+	if username != "" {
+		q.Set("username", username)
+	}
+	return s.checkLink("verify_email", q, verifyEmailPages, unableToVerifyEmailMessage)
+}
+
+// CheckPasswordResetToken checks the token from a password reset email before
+// showing a page to choose the new password.
+func (s *User) CheckPasswordResetToken(username string, token string) *Error {
+	q := url.Values{}
+	q.Set("token", token)
+	if username != "" {
+		q.Set("username", username)
+	}
+	return s.checkLink("request_password_reset", q, passwordResetPages, unableToCheckResetMessage)
+}
+
+// ResetPassword sets a new password with the token from a password reset email.
+func (s *User) ResetPassword(username string, token string, newPassword string) *Error {
+	// create the URL
+	resetUrl, _ := url.Parse(fmt.Sprintf("/apps/%s/request_password_reset", url.PathEscape(s.applicationId)))
+	joinedUrl := s.baseUrl.ResolveReference(resetUrl)
+
+	// create the body, the page expects a form
+	form := url.Values{}
+	form.Set("username", username)
+	form.Set("token", token)
+	form.Set("new_password", newPassword)
+
+	// create the request, as XHR so the result is a status instead of a redirect
+	req, _ := http.NewRequest("POST", joinedUrl.String(), strings.NewReader(form.Encode()))
+	req.Header.Add(contentTypeHeader, formContentTypeValue)
+	req.Header.Add(requestedWithHeader, "XMLHttpRequest")
+
+	// Make the request
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code
+	if resp.StatusCode != http.StatusOK {
+		// Parse the response
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result == nil || (result["error"] == nil && result["code"] == nil) {
+			return &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(unableToResetPasswordMessage),
+			}
+		}
+		message := getErrorMessage(result["error"].(string), unableToResetPasswordMessage)
+		return &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: result["code"].(float64),
+			Err:           errors.New(message),
+		}
+	}
+
+	return nil
+}
+
+func (s *User) checkLink(page string, query url.Values, pages linkPages, defaultError string) *Error {
+	// create the URL
+	pageUrl, _ := url.Parse(fmt.Sprintf("/apps/%s/%s", url.PathEscape(s.applicationId), url.PathEscape(page)))
+	pageUrl.RawQuery = query.Encode()
+	joinedUrl := s.baseUrl.ResolveReference(pageUrl)
+
+	// create the request
+	req, _ := http.NewRequest("GET", joinedUrl.String(), nil)
+
+	// Make the request, the answer is a redirect to a success or an invalid link page
+	client := *s.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code
+	if resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusSeeOther {
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Err:        errors.New(defaultError),
+		}
+	}
+
+	// a page with an error is a failure whatever its name
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Err:        errors.New(defaultError),
+		}
+	}
+	name := path.Base(location.Path)
+	switch {
+	case location.Query().Get("error") != "" || containsPage(pages.invalid, name):
+		return &Error{
+			StatusCode: http.StatusBadRequest,
+			Err:        ErrInvalidLink,
+		}
+	case containsPage(pages.success, name):
+		return nil
+	}
+
+	// a custom page cannot be told apart
+	return &Error{
+		StatusCode: resp.StatusCode,
+		Err:        errors.New(defaultError),
+	}
+}
+
+func containsPage(pages []string, name string) bool {
+	for _, page := range pages {
+		if page == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package user
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/apps/applicationId/verify_email", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("token"))
+		assert.Equal(t, "username", r.URL.Query().Get("username"))
+		http.Redirect(w, r, "/apps/verify_email_success.html?username=username", http.StatusFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	err := s.VerifyEmail("username", "token")
+	assert.Nil(t, err)
+}
+
+func TestVerifyEmailInvalidLink(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("username"))
+		http.Redirect(w, r, "/apps/invalid_verification_link.html", http.StatusFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	err := s.VerifyEmail("", "token")
+	assert.ErrorIs(t, err, ErrInvalidLink)
+	assert.Equal(t, "invalid or expired link: 400", err.Error())
+}
+
+func TestVerifyEmailExpiredLink(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("username") == "legacy" {
+			http.Redirect(w, r, "/apps/invalid_verification_link.html?username=legacy&appId=applicationId", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/apps/email_verification_link_expired.html?username=username", http.StatusFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	assert.ErrorIs(t, s.VerifyEmail("username", "token"), ErrInvalidLink)
+	assert.ErrorIs(t, s.VerifyEmail("legacy", "token"), ErrInvalidLink)
+}
+
+func TestVerifyEmailUnknownPage(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("username") == "error" {
+			http.Redirect(w, r, "/apps/verify_email_success.html?error=expired", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "https://example.com/welcome", http.StatusFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	assert.ErrorIs(t, s.VerifyEmail("error", "token"), ErrInvalidLink)
+	assert.Equal(t, "unable to verify email: 302", s.VerifyEmail("username", "token").Error())
+}
+
+func TestVerifyEmailError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	err := s.VerifyEmail("username", "token")
+	assert.Equal(t, "unable to verify email: 404", err.Error())
+}
+
+func TestCheckPasswordResetToken(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/apps/applicationId/request_password_reset", r.URL.Path)
+		assert.Equal(t, "token", r.URL.Query().Get("token"))
+		if r.URL.Query().Get("username") == "expired" {
+			http.Redirect(w, r, "/apps/invalid_link.html", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/apps/choose_password?token=token&id=applicationId&username=username", http.StatusFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	assert.Nil(t, s.CheckPasswordResetToken("username", "token"))
+	assert.ErrorIs(t, s.CheckPasswordResetToken("expired", "token"), ErrInvalidLink)
+}
+
+func TestResetPassword(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/apps/applicationId/request_password_reset", r.URL.Path)
+		assert.Equal(t, "XMLHttpRequest", r.Header.Get("X-Requested-With"))
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, "username", r.PostForm.Get("username"))
+		assert.Equal(t, "token", r.PostForm.Get("token"))
+		assert.Equal(t, "n3w&password", r.PostForm.Get("new_password"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`Password successfully reset`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	err := s.ResetPassword("username", "token", "n3w&password")
+	assert.Nil(t, err)
+}
+
+func TestResetPasswordHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":-1,"error":"Failed to reset password: username / email or token is invalid"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	err := s.ResetPassword("username", "token", "password")
+	assert.Equal(t, "Failed to reset password: username / email or token is invalid: 400", err.Error())
+}
+
+func TestResetPasswordError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	err := s.ResetPassword("username", "token", "password")
+	assert.Equal(t, "unable to reset password: 400", err.Error())
+}
@@ -0,0 +1,197 @@
+package user
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const sessionFileMode = 0600
+
+var ErrSessionDecrypt = errors.New("unable to decrypt session")
+
+type SessionStore interface {
+	Load() (map[string]interface{}, error)
+	Save(session map[string]interface{}) error
+	Clear() error
+}
+
+type MemorySessionStore struct {
+	mu      sync.Mutex
+	session []byte
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{}
+}
+
+func (m *MemorySessionStore) Load() (map[string]interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.session == nil {
+		return nil, nil
+	}
+	var session map[string]interface{}
+	if err := json.Unmarshal(m.session, &session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (m *MemorySessionStore) Save(session map[string]interface{}) error {
+	// keep an encoded copy so later changes to the map are not stored
+	marshalled, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session = marshalled
+	return nil
+}
+
+func (m *MemorySessionStore) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.session = nil
+	return nil
+}
+
+type FileSessionStoreOption func(*FileSessionStore)
+
+type FileSessionStore struct {
+	mu   sync.Mutex
+	path string
+	key  []byte
+}
+
+// WithEncryptionKey encrypts the session file with AES-GCM, the key must be
+// 16, 24 or 32 bytes long.
+func WithEncryptionKey(key []byte) FileSessionStoreOption {
+	return func(f *FileSessionStore) {
+		f.key = key
+	}
+}
+
+func NewFileSessionStore(path string, options ...FileSessionStoreOption) *FileSessionStore {
+	f := &FileSessionStore{path: path}
+	for _, option := range options {
+		option(f)
+	}
+	return f
+}
+
+func (f *FileSessionStore) Load() (map[string]interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// no file means no one has logged in yet
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f.key != nil {
+		if data, err = f.decrypt(data); err != nil {
+			return nil, err
+		}
+	}
+
+	var session map[string]interface{}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (f *FileSessionStore) Save(session map[string]interface{}) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if f.key != nil {
+		if data, err = f.encrypt(data); err != nil {
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// write to a temporary file and rename it, so a crash never leaves half a session
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if err := tmp.Chmod(sessionFileMode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *FileSessionStore) Clear() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := os.Remove(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileSessionStore) encrypt(data []byte) ([]byte, error) {
+	gcm, err := f.gcm()
+	if err != nil {
+		return nil, err
+	}
+	// the nonce is stored in front of the sealed data
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func (f *FileSessionStore) decrypt(data []byte) ([]byte, error) {
+	gcm, err := f.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrSessionDecrypt
+	}
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	opened, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, ErrSessionDecrypt
+	}
+	return opened, nil
+}
+
+func (f *FileSessionStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,157 @@
+package user
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMemorySessionStore(t *testing.T) {
+	store := NewMemorySessionStore()
+	session, err := store.Load()
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	saved := map[string]interface{}{"sessionToken": "sessionToken"}
+	assert.Nil(t, store.Save(saved))
+	saved["sessionToken"] = "changed"
+	session, err = store.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", session["sessionToken"])
+
+	assert.Nil(t, store.Clear())
+	session, _ = store.Load()
+	assert.Nil(t, session)
+}
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	store := NewFileSessionStore(path)
+	session, err := store.Load()
+	assert.Nil(t, err)
+	assert.Nil(t, session)
+
+	assert.Nil(t, store.Save(map[string]interface{}{"sessionToken": "sessionToken"}))
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, _ := os.ReadFile(path)
+	assert.JSONEq(t, `{"sessionToken":"sessionToken"}`, string(data))
+
+	session, err = NewFileSessionStore(path).Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", session["sessionToken"])
+
+	assert.Nil(t, store.Clear())
+	assert.NoFileExists(t, path)
+	assert.Nil(t, store.Clear())
+}
+
+func TestFileSessionStoreEncrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session")
+	key := []byte("0123456789abcdef0123456789abcdef")
+	store := NewFileSessionStore(path, WithEncryptionKey(key))
+	assert.Nil(t, store.Save(map[string]interface{}{"sessionToken": "sessionToken"}))
+	data, _ := os.ReadFile(path)
+	assert.NotContains(t, string(data), "sessionToken")
+
+	session, err := NewFileSessionStore(path, WithEncryptionKey(key)).Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "sessionToken", session["sessionToken"])
+
+	session, err = NewFileSessionStore(path, WithEncryptionKey([]byte("fedcba9876543210fedcba9876543210"))).Load()
+	assert.Nil(t, session)
+	assert.ErrorIs(t, err, ErrSessionDecrypt)
+}
+
+func TestFileSessionStoreInvalidKey(t *testing.T) {
+	store := NewFileSessionStore(filepath.Join(t.TempDir(), "session"), WithEncryptionKey([]byte("short")))
+	assert.Error(t, store.Save(map[string]interface{}{"sessionToken": "sessionToken"}))
+}
+
+func TestSessionStoreLoginLogout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/login" {
+			_, _ = w.Write([]byte(`{"objectId":"userId","sessionToken":"sessionToken"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	store := NewMemorySessionStore()
+
+	s := NewUser("applicationId", "restApiKey", nil, b, WithSessionStore(store))
+	_, err := s.Login("username", "password")
+	assert.Nil(t, err)
+	session, _ := store.Load()
+	assert.Equal(t, "sessionToken", session["sessionToken"])
+
+	// a new process picks up the stored session
+	restored := NewUser("applicationId", "restApiKey", nil, b, WithSessionStore(store))
+	assert.Equal(t, "sessionToken", restored.SessionToken())
+
+	assert.Nil(t, restored.Logout())
+	session, _ = store.Load()
+	assert.Nil(t, session)
+}
+
+func TestSessionStoreNotUsedForSession(t *testing.T) {
+	store := NewMemorySessionStore()
+	_ = store.Save(map[string]interface{}{"sessionToken": "stored"})
+	s := NewUser("applicationId", "restApiKey", nil, nil, WithSessionStore(store))
+	u := s.ForSession("sessionToken")
+	assert.Equal(t, "sessionToken", u.SessionToken())
+	session, _ := store.Load()
+	assert.Equal(t, "stored", session["sessionToken"])
+}
+
+type failingSessionStore struct {
+	MemorySessionStore
+}
+
+func (f *failingSessionStore) Load() (map[string]interface{}, error) {
+	return nil, errors.New("unable to read session")
+}
+
+func TestSessionStoreLoadError(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil, WithSessionStore(&failingSessionStore{}))
+	assert.EqualError(t, s.LoadError(), "unable to read session")
+	assert.Empty(t, s.SessionToken())
+
+	s = NewUser("applicationId", "restApiKey", nil, nil, WithSessionStore(NewMemorySessionStore()))
+	assert.Nil(t, s.LoadError())
+}
+
+type blockingSessionStore struct {
+	MemorySessionStore
+	saving  chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingSessionStore) Save(session map[string]interface{}) error {
+	b.saving <- struct{}{}
+	<-b.release
+	return b.MemorySessionStore.Save(session)
+}
+
+func TestSessionStoreSaveDoesNotBlockReaders(t *testing.T) {
+	store := &blockingSessionStore{saving: make(chan struct{}), release: make(chan struct{})}
+	s := NewUser("applicationId", "restApiKey", nil, nil, WithSessionStore(store))
+	done := make(chan struct{})
+	go func() {
+		s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+		close(done)
+	}()
+	<-store.saving
+	assert.Equal(t, "sessionToken", s.SessionToken())
+	close(store.release)
+	<-done
+	session, _ := store.Load()
+	assert.Equal(t, "sessionToken", session["sessionToken"])
+}
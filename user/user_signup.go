@@ -7,26 +7,92 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/mail"
 	"net/url"
 )
 
 const unableToSignUpMessage = "unable to sign up user"
 
-func (s *User) SignUp(data map[string]interface{}) (map[string]interface{}, *Error) {
+var (
+	ErrUsernameMissing = errors.New("username is required")
+	ErrPasswordMissing = errors.New("password is required")
+	ErrInvalidEmail    = errors.New("email address is invalid")
+)
+
+type SignUpRequest struct {
+	Username string
+	Password string
+	Email    string
+	// Extra holds custom fields of the user class, the typed fields win over
+	// the same keys here
+	Extra map[string]interface{}
+}
+
+type SignUpOption func(*signUpOptions)
+
+type signUpOptions struct {
+	sessionToken string
+}
+
+// WithSessionToken signs up with the session of an existing user, for apps
+// where only logged in users may create users. The session of the existing
+// user is kept, the new user is not logged in.
+func WithSessionToken(sessionToken string) SignUpOption {
+	return func(o *signUpOptions) {
+		o.sessionToken = sessionToken
+	}
+}
+
+func (r SignUpRequest) validate() error {
+	if r.Username == "" {
+		return ErrUsernameMissing
+	}
+	if r.Password == "" {
+		return ErrPasswordMissing
+	}
+	if r.Email != "" {
+		if address, err := mail.ParseAddress(r.Email); err != nil || address.Address != r.Email {
+			return ErrInvalidEmail
+		}
+	}
+	return nil
+}
+
+func (s *User) SignUp(request SignUpRequest, options ...SignUpOption) (*Profile, *Error) {
+	opts := &signUpOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	// check the request before it reaches the server
+	if err := request.validate(); err != nil {
+		return nil, &Error{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		}
+	}
+
 	// create the URL
 	usersUrl, _ := url.Parse("/users")
 	createUserUrl := s.baseUrl.ResolveReference(usersUrl)
 
-	// Set the session token
-	var sessionToken = ""
-	if data["sessionToken"] != nil {
-		sessionToken = data["sessionToken"].(string)
-		// remove the session token from the data
-		delete(data, "sessionToken")
+	// create the body from a copy, the request is never changed
+	data := make(map[string]interface{}, len(request.Extra)+3)
+	for k, v := range request.Extra {
+		data[k] = v
+	}
+	data["username"] = request.Username
+	data["password"] = request.Password
+	if request.Email != "" {
+		data["email"] = request.Email
+	}
+	marshalled, err := json.Marshal(data)
+	if err != nil {
+		return nil, &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
 	}
-
-	// create the body
-	marshalled, _ := json.Marshal(data)
 
 	// create the request
 	req, _ := http.NewRequest("POST", createUserUrl.String(), bytes.NewReader(marshalled))
@@ -36,12 +102,12 @@ func (s *User) SignUp(data map[string]interface{}) (map[string]interface{}, *Err
 	req.Header.Add(revocableHeader, "1")
 
 	// If we have a session token, add it to the request
-	if sessionToken != "" {
-		req.Header.Add(sessionTokenHeader, sessionToken)
+	if opts.sessionToken != "" {
+		req.Header.Add(sessionTokenHeader, opts.sessionToken)
 	}
 
 	// Make the request
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return nil, &Error{
@@ -85,7 +151,9 @@ func (s *User) SignUp(data map[string]interface{}) (map[string]interface{}, *Err
 		}
 	}
 
-	// Save the session
-	s.Session = result
-	return result, nil
+	// Save the session, unless a logged in user created another user
+	if opts.sessionToken == "" {
+		s.setSession(result)
+	}
+	return newProfile(result), nil
 }
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -10,35 +11,97 @@ import (
 
 func TestSignUp(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/users", r.URL.Path)
+		assert.Empty(t, r.Header.Get("X-Parse-Session-Token"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"username": "username", "password": "password"}, body)
 		w.WriteHeader(http.StatusCreated)
 		_, _ = w.Write([]byte(`{"sessionToken":"sessionToken"}`))
 	}))
 	defer svr.Close()
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
-	var data = make(map[string]interface{})
-	data["username"] = "username"
-	data["password"] = "password"
-	u, _ := s.SignUp(data)
-	assert.NotEmptyf(t, u["sessionToken"], "Expected sessionToken to be initialized")
-	assert.NotEmptyf(t, s.Session["sessionToken"], "Expected sessionToken to be initialized")
+	u, _ := s.SignUp(SignUpRequest{Username: "username", Password: "password"})
+	assert.NotEmptyf(t, u.SessionToken, "Expected sessionToken to be initialized")
+	assert.NotEmptyf(t, s.SessionToken(), "Expected sessionToken to be initialized")
 }
 
 func TestSignUpWithSessionToken(t *testing.T) {
 	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.NotContains(t, body, "sessionToken")
 		w.WriteHeader(http.StatusCreated)
-		_, _ = w.Write([]byte(`{"objectId":"objectId","createdAt":"createdAt"}`))
+		_, _ = w.Write([]byte(`{"objectId":"objectId","createdAt":"2023-01-01T00:00:00.000Z"}`))
 	}))
 	defer svr.Close()
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
-	var data = make(map[string]interface{})
-	data["username"] = "username"
-	data["password"] = "password"
-	data["sessionToken"] = "sessionToken"
-	u, _ := s.SignUp(data)
-	assert.NotEmptyf(t, u["objectId"], "Expected objectId to be initialized")
-	assert.NotEmptyf(t, u["createdAt"], "Expected createdAt to be initialized")
+	u, _ := s.SignUp(SignUpRequest{Username: "username", Password: "password"}, WithSessionToken("sessionToken"))
+	assert.NotEmptyf(t, u.ObjectID, "Expected objectId to be initialized")
+	assert.NotEmptyf(t, u.CreatedAt, "Expected createdAt to be initialized")
+}
+
+func TestSignUpWithSessionTokenKeepsSession(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"newUserId","sessionToken":"newSessionToken"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	store := NewMemorySessionStore()
+	s := NewUser("applicationId", "restApiKey", nil, b, WithSessionStore(store))
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	u, err := s.SignUp(SignUpRequest{Username: "username", Password: "password"}, WithSessionToken(s.SessionToken()))
+	assert.Nil(t, err)
+	assert.Equal(t, "newUserId", u.ObjectID)
+	assert.Equal(t, "sessionToken", s.SessionToken())
+	session, _ := store.Load()
+	assert.Equal(t, "userId", session["objectId"])
+}
+
+func TestSignUpEmailAndExtra(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{
+			"username": "username",
+			"password": "password",
+			"email":    "user@example.com",
+			"nickname": "nick",
+		}, body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"objectId":"objectId"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	extra := map[string]interface{}{"nickname": "nick", "username": "ignored"}
+	_, err := s.SignUp(SignUpRequest{Username: "username", Password: "password", Email: "user@example.com", Extra: extra})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"nickname": "nick", "username": "ignored"}, extra)
+}
+
+func TestSignUpValidation(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	tests := []struct {
+		request SignUpRequest
+		err     error
+	}{
+		{SignUpRequest{Password: "password"}, ErrUsernameMissing},
+		{SignUpRequest{Username: "username"}, ErrPasswordMissing},
+		{SignUpRequest{Username: "username", Password: "password", Email: "not an email"}, ErrInvalidEmail},
+		{SignUpRequest{Username: "username", Password: "password", Email: "User <user@example.com>"}, ErrInvalidEmail},
+	}
+	for _, test := range tests {
+		u, err := s.SignUp(test.request)
+		assert.Nil(t, u)
+		assert.ErrorIs(t, err, test.err)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	}
 }
 
 func TestSignUpError(t *testing.T) {
@@ -48,11 +111,8 @@ func TestSignUpError(t *testing.T) {
 	defer svr.Close()
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
-	var data = make(map[string]interface{})
-	data["username"] = "username"
-	data["password"] = "password"
-	u, err := s.SignUp(data)
-	assert.Equal(t, nil, u["sessionToken"])
+	u, err := s.SignUp(SignUpRequest{Username: "username", Password: "password"})
+	assert.Nil(t, u)
 	assert.Error(t, err)
 	assert.Equal(t, "unable to sign up user: 400", err.Error())
 }
@@ -65,11 +125,8 @@ func TestSignUpHostError(t *testing.T) {
 	defer svr.Close()
 	b, _ := url.Parse(svr.URL)
 	s := NewUser("applicationId", "restApiKey", nil, b)
-	var data = make(map[string]interface{})
-	data["username"] = "username"
-	data["password"] = "password"
-	u, err := s.SignUp(data)
-	assert.Equal(t, nil, u["sessionToken"])
+	u, err := s.SignUp(SignUpRequest{Username: "username", Password: "password"})
+	assert.Nil(t, u)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid login parameters: 400", err.Error())
 }
@@ -1,7 +1,13 @@
 package user
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
 	"testing"
 )
 
@@ -10,3 +16,52 @@ func TestNewUser(t *testing.T) {
 	assert.NotNil(t, s.client)
 	assert.NotNil(t, s.baseUrl)
 }
+
+func TestCurrentSessionReturnsCopy(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	assert.Nil(t, s.CurrentSession())
+	assert.Empty(t, s.SessionToken())
+
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	session := s.CurrentSession()
+	session["sessionToken"] = "changed"
+	assert.Equal(t, "sessionToken", s.SessionToken())
+}
+
+func TestForSession(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	s.setSession(map[string]interface{}{"sessionToken": "shared"})
+	u := s.ForSession("sessionToken")
+	assert.Equal(t, "sessionToken", u.SessionToken())
+	assert.Equal(t, "shared", s.SessionToken())
+	assert.Same(t, s.client, u.client)
+	assert.Equal(t, s.baseUrl, u.baseUrl)
+}
+
+func TestConcurrentLogins(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"sessionToken":"%s"}`, body["username"])))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = s.Login(fmt.Sprintf("user%d", i), "password")
+		}(i)
+		go func() {
+			defer wg.Done()
+			_ = s.SessionToken()
+			_ = s.CurrentSession()
+		}()
+	}
+	wg.Wait()
+	assert.Regexp(t, `^user\d+$`, s.SessionToken())
+}
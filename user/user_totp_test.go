@@ -0,0 +1,51 @@
+package user
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGenerateTOTP(t *testing.T) {
+	// RFC 6238 test vectors for SHA1, truncated to six digits
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	tests := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range tests {
+		code, err := GenerateTOTP(secret, time.Unix(unix, 0))
+		assert.Nil(t, err)
+		assert.Equal(t, want, code)
+	}
+}
+
+func TestGenerateTOTPInvalidSecret(t *testing.T) {
+	_, err := GenerateTOTP("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+	other, _ := NewTOTPSecret()
+	assert.NotEqual(t, secret, other)
+	_, err = GenerateTOTP(secret, time.Now())
+	assert.Nil(t, err)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, _ := url.Parse(TOTPURI("SECRET", "My App", "user@example.com"))
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/My App:user@example.com", uri.Path)
+	assert.Equal(t, "SECRET", uri.Query().Get("secret"))
+	assert.Equal(t, "My App", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
@@ -0,0 +1,199 @@
+package user
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	unableToUpdateUserMessage = "unable to update user"
+	unableToGetUserMessage    = "unable to get user"
+	unableToListUsersMessage  = "unable to list users"
+	unableToDeleteUserMessage = "unable to delete user"
+)
+
+type ListOption func(query url.Values) error
+
+func WithWhere(constraints map[string]interface{}) ListOption {
+	return func(query url.Values) error {
+		// an empty where would match every user, so a bad constraint fails the query
+		marshalled, err := json.Marshal(constraints)
+		if err != nil {
+			return err
+		}
+		query.Set("where", string(marshalled))
+		return nil
+	}
+}
+
+func WithLimit(i int) ListOption {
+	return func(query url.Values) error {
+		query.Set("limit", strconv.Itoa(i))
+		return nil
+	}
+}
+
+func WithSkip(i int) ListOption {
+	return func(query url.Values) error {
+		query.Set("skip", strconv.Itoa(i))
+		return nil
+	}
+}
+
+func WithOrder(fields ...string) ListOption {
+	return func(query url.Values) error {
+		query.Set("order", strings.Join(fields, ","))
+		return nil
+	}
+}
+
+func WithKeys(keys ...string) ListOption {
+	return func(query url.Values) error {
+		query.Set("keys", strings.Join(keys, ","))
+		return nil
+	}
+}
+
+func (s *User) UpdateUser(userId string, data map[string]interface{}) (bool, *Error) {
+	// only a logged in user can update a user
+	sessionToken := s.SessionToken()
+	if sessionToken == "" {
+		return false, &Error{
+			StatusCode: http.StatusUnauthorized,
+			Err:        ErrNotLoggedIn,
+		}
+	}
+	if err := s.users("PUT", fmt.Sprintf("/users/%s", url.PathEscape(userId)), nil, data, sessionToken, nil, unableToUpdateUserMessage); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *User) GetUser(userId string) (*Profile, *Error) {
+	sessionToken := s.SessionToken()
+	var result Profile
+	if err := s.users("GET", fmt.Sprintf("/users/%s", url.PathEscape(userId)), nil, nil, sessionToken, &result, unableToGetUserMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *User) ListUsers(option ...ListOption) ([]*Profile, *Error) {
+	q := url.Values{}
+	for _, opt := range option {
+		if err := opt(q); err != nil {
+			return nil, &Error{StatusCode: 500, Err: err}
+		}
+	}
+	sessionToken := s.SessionToken()
+	var result struct {
+		Results []*Profile `json:"results"`
+	}
+	if err := s.users("GET", "/users", q, nil, sessionToken, &result, unableToListUsersMessage); err != nil {
+		return nil, err
+	}
+	return result.Results, nil
+}
+
+func (s *User) DeleteUser(userId string) (bool, *Error) {
+	// only a logged in user can delete a user
+	sessionToken := s.SessionToken()
+	if sessionToken == "" {
+		return false, &Error{
+			StatusCode: http.StatusUnauthorized,
+			Err:        ErrNotLoggedIn,
+		}
+	}
+	if err := s.users("DELETE", fmt.Sprintf("/users/%s", url.PathEscape(userId)), nil, nil, sessionToken, nil, unableToDeleteUserMessage); err != nil {
+		return false, err
+	}
+
+	// the session of a deleted user is gone
+	if s.CurrentSession()["objectId"] == userId {
+		s.clearSession()
+	}
+	return true, nil
+}
+
+func (s *User) users(method string, path string, query url.Values, body interface{}, sessionToken string, out interface{}, defaultError string) *Error {
+	// create the URL
+	userUrl, _ := url.Parse(path)
+	userUrl.RawQuery = query.Encode()
+	joinedUrl := s.baseUrl.ResolveReference(userUrl)
+
+	// create the body
+	var reader io.Reader
+	if body != nil {
+		marshalled, err := json.Marshal(body)
+		if err != nil {
+			return &Error{StatusCode: 500, Err: err}
+		}
+		reader = bytes.NewReader(marshalled)
+	}
+
+	// create the request
+	req, _ := http.NewRequest(method, joinedUrl.String(), reader)
+	req.Header.Add(contentTypeHeader, contentTypeValue)
+	req.Header.Add(applicationIdHeader, s.applicationId)
+	req.Header.Add(restApiKeyHeader, s.restApiKey)
+	if sessionToken != "" {
+		req.Header.Add(sessionTokenHeader, sessionToken)
+	}
+
+	// Make the request
+	resp, err := s.do(req)
+	if err != nil {
+		log.Println("Error: ", err)
+		return &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Println("Error: ", err)
+		}
+	}(resp.Body)
+
+	// check the status code
+	if resp.StatusCode != http.StatusOK {
+		// Parse the response
+		var result map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&result)
+		if result == nil || (result["error"] == nil && result["code"] == nil) {
+			return &Error{
+				StatusCode: resp.StatusCode,
+				Err:        errors.New(defaultError),
+			}
+		}
+		message := getErrorMessage(result["error"].(string), defaultError)
+		return &Error{
+			StatusCode:    resp.StatusCode,
+			HostErrorCode: result["code"].(float64),
+			Err:           errors.New(message),
+		}
+	}
+
+	// Parse the response
+	if out == nil {
+		return nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return &Error{
+			StatusCode: 500,
+			Err:        err,
+		}
+	}
+
+	return nil
+}
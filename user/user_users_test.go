@@ -0,0 +1,186 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestUpdateUser(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/users/userId", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "new@example.com", body["email"])
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"updatedAt":"updatedAt"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	isUpdated, err := s.UpdateUser("userId", map[string]interface{}{"email": "new@example.com"})
+	assert.Nil(t, err)
+	assert.True(t, isUpdated)
+}
+
+func TestUpdateUserNotLoggedIn(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	isUpdated, err := s.UpdateUser("userId", map[string]interface{}{"email": "new@example.com"})
+	assert.False(t, isUpdated)
+	assert.True(t, errors.Is(err, ErrNotLoggedIn))
+}
+
+func TestUpdateUserHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":206,"error":"Cannot modify user userId."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "otherId", "sessionToken": "sessionToken"})
+	isUpdated, err := s.UpdateUser("userId", map[string]interface{}{"email": "new@example.com"})
+	assert.False(t, isUpdated)
+	assert.Error(t, err)
+	assert.Equal(t, "Cannot modify user userId.: 400", err.Error())
+}
+
+func TestGetUser(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/users/userId", r.URL.Path)
+		assert.Empty(t, r.Header.Get("X-Parse-Session-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"userId","username":"username"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.GetUser("userId")
+	assert.Nil(t, err)
+	assert.Equal(t, "username", u.Username)
+}
+
+func TestGetUserEscapesId(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users/a%2Fb%3Fc", r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"a/b?c"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.GetUser("a/b?c")
+	assert.Nil(t, err)
+	assert.Equal(t, "a/b?c", u.ObjectID)
+}
+
+func TestGetUserError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.GetUser("userId")
+	assert.Nil(t, u)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to get user: 404", err.Error())
+}
+
+func TestListUsers(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		assert.Equal(t, `{"emailVerified":true}`, r.URL.Query().Get("where"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "0", r.URL.Query().Get("skip"))
+		assert.Equal(t, "-createdAt", r.URL.Query().Get("order"))
+		assert.Equal(t, "username,email", r.URL.Query().Get("keys"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results":[{"objectId":"a"},{"objectId":"b"}]}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"sessionToken": "sessionToken"})
+	users, err := s.ListUsers(WithWhere(map[string]interface{}{"emailVerified": true}), WithLimit(10), WithSkip(0), WithOrder("-createdAt"), WithKeys("username", "email"))
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "b", users[1].ObjectID)
+}
+
+func TestListUsersWithWhereMarshalError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fail()
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	users, err := s.ListUsers(WithWhere(map[string]interface{}{"channel": make(chan int)}))
+	assert.Nil(t, users)
+	assert.Equal(t, 500, err.StatusCode)
+	assert.Equal(t, "json: unsupported type: chan int: 500", err.Error())
+}
+
+func TestListUsersHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":102,"error":"Invalid query"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	users, err := s.ListUsers()
+	assert.Nil(t, users)
+	assert.Error(t, err)
+	assert.Equal(t, "Invalid query: 400", err.Error())
+}
+
+func TestDeleteUser(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "/users/userId", r.URL.Path)
+		assert.Equal(t, "sessionToken", r.Header.Get("X-Parse-Session-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	isDeleted, err := s.DeleteUser("userId")
+	assert.Nil(t, err)
+	assert.True(t, isDeleted)
+	assert.Nil(t, s.CurrentSession())
+}
+
+func TestDeleteUserNotLoggedIn(t *testing.T) {
+	s := NewUser("applicationId", "restApiKey", nil, nil)
+	isDeleted, err := s.DeleteUser("userId")
+	assert.False(t, isDeleted)
+	assert.True(t, errors.Is(err, ErrNotLoggedIn))
+}
+
+func TestDeleteUserError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	s.setSession(map[string]interface{}{"objectId": "userId", "sessionToken": "sessionToken"})
+	isDeleted, err := s.DeleteUser("userId")
+	assert.False(t, isDeleted)
+	assert.Error(t, err)
+	assert.Equal(t, "unable to delete user: 400", err.Error())
+	assert.NotNil(t, s.CurrentSession())
+}
@@ -28,7 +28,7 @@ func (s *User) VerificationEmailRequest(email string) *Error {
 	req.Header.Add(restApiKeyHeader, s.restApiKey)
 
 	// Make the request
-	resp, err := s.client.Do(req)
+	resp, err := s.do(req)
 	if err != nil {
 		log.Println("Error: ", err)
 		return &Error{
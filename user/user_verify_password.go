@@ -0,0 +1,14 @@
+package user
+
+const unableToVerifyPasswordMessage = "unable to verify password"
+
+// VerifyPassword checks the credentials of a user without creating a session.
+func (s *User) VerifyPassword(username string, password string) (*Profile, *Error) {
+	// post the credentials, a query string ends up in access logs
+	body := map[string]string{"username": username, "password": password}
+	var result Profile
+	if err := s.users("POST", "/verifyPassword", nil, body, "", &result, unableToVerifyPasswordMessage); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,56 @@
+package user
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestVerifyPassword(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/verifyPassword", r.URL.Path)
+		assert.Empty(t, r.URL.RawQuery)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"username": "username", "password": "p&ssword"}, body)
+		assert.Empty(t, r.Header.Get("X-Parse-Session-Token"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"objectId":"userId","username":"username"}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.VerifyPassword("username", "p&ssword")
+	assert.Nil(t, err)
+	assert.Equal(t, "userId", u.ObjectID)
+	assert.Empty(t, s.SessionToken())
+}
+
+func TestVerifyPasswordHostError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":101,"error":"Invalid username/password."}`))
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	u, err := s.VerifyPassword("username", "password")
+	assert.Nil(t, u)
+	assert.Equal(t, float64(101), err.HostErrorCode)
+	assert.Equal(t, "Invalid username/password.: 404", err.Error())
+}
+
+func TestVerifyPasswordError(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer svr.Close()
+	b, _ := url.Parse(svr.URL)
+	s := NewUser("applicationId", "restApiKey", nil, b)
+	_, err := s.VerifyPassword("username", "password")
+	assert.Equal(t, "unable to verify password: 400", err.Error())
+}